@@ -0,0 +1,45 @@
+package discreteprobability
+
+import (
+	"math"
+	"sort"
+)
+
+// Allocate splits total across the values proportionally to their weights with
+// largest-remainder rounding, so the counts always sum to total.
+// The values must be comparable to be used as map keys.
+func (g *Generator) Allocate(total int) map[interface{}]int {
+	values, weights := g.Distribution()
+	counts := allocate(weights, total)
+
+	allocation := make(map[interface{}]int, len(values))
+	for i, value := range values {
+		allocation[value] = counts[i]
+	}
+	return allocation
+}
+
+// allocate returns the counts of total split by the weights with largest-remainder rounding.
+func allocate(weights []float64, total int) []int {
+	counts := make([]int, len(weights))
+	remainders := make([]float64, len(weights))
+	order := make([]int, len(weights))
+
+	allocated := 0
+	for i, weight := range weights {
+		share := weight * float64(total)
+		counts[i] = int(math.Floor(share))
+		remainders[i] = share - float64(counts[i])
+		allocated += counts[i]
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]] > remainders[order[j]]
+	})
+	for i := 0; allocated < total && len(order) > 0; i = (i + 1) % len(order) {
+		counts[order[i]]++
+		allocated++
+	}
+	return counts
+}
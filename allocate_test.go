@@ -0,0 +1,25 @@
+package discreteprobability
+
+import "testing"
+
+func TestAllocate(t *testing.T) {
+	g, _ := New([]string{"a", "b", "c"}, []float64{1.0 / 3, 1.0 / 3, 1.0 / 3})
+	allocation := g.Allocate(1000)
+	sum := 0
+	for value, count := range allocation {
+		if count != 333 && count != 334 {
+			t.Errorf("unexpected allocation of %v: %v", value, count)
+		}
+		sum += count
+	}
+	if sum != 1000 {
+		t.Errorf("expected allocation sum 1000, got %v", sum)
+	}
+
+	g, _ = New([]int{1, 2, 3}, []float64{0.5, 0.3, 0.2})
+	allocation = g.Allocate(7)
+	// shares are 3.5, 2.1, 1.4
+	if allocation[1] != 4 || allocation[2] != 2 || allocation[3] != 1 {
+		t.Errorf("unexpected allocation %v", allocation)
+	}
+}
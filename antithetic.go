@@ -0,0 +1,18 @@
+package discreteprobability
+
+// RandomAntithetic returns the pair of values at the uniforms u and 1-u of the CDF in
+// ascending order of the values, a Monte Carlo variance reduction: each value of the pair
+// has the distribution of the generator, and they are negatively correlated, so the mean
+// of a monotone function over the pairs has a lower variance than over independent draws.
+// Values of kinds which can't be compared keep the order of the generator.
+// The values are sorted on the first draw, and the order is kept for the next ones.
+func (g *Generator) RandomAntithetic() (interface{}, interface{}) {
+	order, cdf := g.valueCDF()
+	u := uniform(g.source)
+	// u is in [0, 1), so 1-u is in (0, 1] and never picks a leading value with zero weight
+	a := order[searchCDF(cdf, u)]
+	b := order[searchCDF(cdf, 1-u)]
+	g.observe(a, false)
+	g.observe(b, false)
+	return g.values[a].Interface(), g.values[b].Interface()
+}
@@ -0,0 +1,33 @@
+package discreteprobability
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRandomAntithetic(t *testing.T) {
+	g, _ := New([]int{1, 2, 3}, []float64{0.25, 0.25, 0.5})
+	g.SetSeed(time.Now().Unix())
+
+	var antithetic, independent float64
+	occurrence := map[interface{}]float64{}
+	for i := 0; i < repeats; i++ {
+		a, b := g.RandomAntithetic()
+		occurrence[a]++
+		occurrence[b]++
+		mean := float64(a.(int)+b.(int)) / 2
+		antithetic += (mean - 2.25) * (mean - 2.25)
+		mean = float64(g.RandomInt()+g.RandomInt()) / 2
+		independent += (mean - 2.25) * (mean - 2.25)
+	}
+
+	for v, w := range map[int]float64{1: 0.25, 2: 0.25, 3: 0.5} {
+		p := w * 2 * repeats
+		if d := p * 3 / 100; occurrence[v] > p+d || occurrence[v] < p-d {
+			t.Errorf("incorrect distribution value %v, expected %f, got %f", v, p, occurrence[v])
+		}
+	}
+	if antithetic >= independent/2 {
+		t.Errorf("expected a lower variance of the antithetic pairs, got %v and %v", antithetic, independent)
+	}
+}
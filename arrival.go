@@ -0,0 +1,41 @@
+package discreteprobability
+
+import (
+	"math/rand"
+	"time"
+)
+
+// ArrivalProcess generates the events of a Poisson process, e.g. the requests of a simulated
+// service: the times between the events are exponential, and the type of each event is drawn
+// from a Generator.
+type ArrivalProcess struct {
+	rate       float64
+	eventTypes *Generator
+	rnd        *rand.Rand
+}
+
+// NewArrivalProcess returns a new ArrivalProcess of rate events per second, whose types are drawn from eventTypes.
+// It will return ErrRate if the rate is not positive
+func NewArrivalProcess(rate float64, eventTypes *Generator) (*ArrivalProcess, error) {
+	if !(rate > 0) {
+		return nil, ErrRate
+	}
+	return &ArrivalProcess{
+		rate:       rate,
+		eventTypes: eventTypes,
+		rnd:        rand.New(rand.NewSource(seed)),
+	}, nil
+}
+
+// SetSeed is to set a custom random seed other than the time stamp. The generator of the
+// event types is reseeded too.
+func (a *ArrivalProcess) SetSeed(s int64) {
+	a.rnd = rand.New(rand.NewSource(s))
+	a.eventTypes.SetSeed(a.rnd.Int63())
+}
+
+// Next returns the time from the previous event to the next one, and the type of the next event.
+func (a *ArrivalProcess) Next() (time.Duration, interface{}) {
+	wait := time.Duration(a.rnd.ExpFloat64() / a.rate * float64(time.Second))
+	return wait, a.eventTypes.RandomInterface()
+}
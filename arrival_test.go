@@ -0,0 +1,41 @@
+package discreteprobability
+
+import (
+	"testing"
+	"time"
+)
+
+func TestArrivalProcess(t *testing.T) {
+	types, _ := New([]string{"read", "write"}, []float64{0.75, 0.25})
+	a, err := NewArrivalProcess(100, types)
+	if err != nil {
+		t.Errorf("NewArrivalProcess error %v", err)
+		t.FailNow()
+	}
+	a.SetSeed(1)
+
+	total := time.Duration(0)
+	writes := float64(0)
+	for i := 0; i < repeats; i++ {
+		wait, event := a.Next()
+		if wait < 0 {
+			t.Errorf("negative wait %v", wait)
+			t.FailNow()
+		}
+		total += wait
+		if event == "write" {
+			writes++
+		}
+	}
+	// 100 events per second is a mean wait of 10ms
+	if mean := total / repeats; mean < 9800*time.Microsecond || mean > 10200*time.Microsecond {
+		t.Errorf("expected a mean wait of 10ms, got %v", mean)
+	}
+	if p := 0.25 * repeats; writes > p*1.03 || writes < p*0.97 {
+		t.Errorf("expected %f writes, got %f", p, writes)
+	}
+
+	if _, err := NewArrivalProcess(0, types); err != ErrRate {
+		t.Errorf("expected ErrRate, got %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+// Package bandit is a multi-armed bandit with epsilon-greedy and Thompson sampling
+// strategies, which draws the exploration from a discreteprobability.Generator.
+// Example usage:
+//
+//		b, err := bandit.New([]string{"red", "green", "blue"})
+//		if err != nil {
+//			panic(err) // Error handlers
+//		}
+//		b.Strategy = bandit.Thompson
+//		arm := b.Select()
+//		b.Reward(arm, 1) // the user clicked
+package bandit
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+// Strategy is the way to balance exploration and exploitation
+type Strategy int
+
+const (
+	// EpsilonGreedy explores a uniformly random arm with the probability Epsilon,
+	// otherwise it selects the arm with the best mean reward
+	EpsilonGreedy Strategy = iota
+	// Thompson draws the mean reward of each arm from its Beta posterior and selects
+	// the best one. The rewards should be in [0, 1]
+	Thompson
+)
+
+// ErrArm is returned when the arm is not one of the arms of the bandit
+var ErrArm = errors.New("unknown arm")
+
+// ErrReward is returned when a reward for Thompson sampling is out of range [0, 1]
+var ErrReward = errors.New("reward out of range")
+
+// Bandit selects arms and learns from their rewards. It's safe for concurrent use.
+type Bandit struct {
+	// Strategy is EpsilonGreedy by default
+	Strategy Strategy
+	// Epsilon is the probability of exploration of EpsilonGreedy, 0.1 by default
+	Epsilon float64
+
+	mu      sync.Mutex
+	arms    []interface{}
+	explore *discreteprobability.Generator
+	rnd     *rand.Rand
+	pulls   []float64
+	rewards []float64
+}
+
+// New returns a new Bandit over the arms, which should be a slice.
+// It will return error if arms is not a slice or is empty.
+func New(arms interface{}) (*Bandit, error) {
+	val := reflect.ValueOf(arms)
+	if val.Kind() != reflect.Slice {
+		return nil, discreteprobability.ErrNotSlice
+	}
+	if val.Len() == 0 {
+		return nil, discreteprobability.ErrNotEnough
+	}
+
+	b := &Bandit{
+		Epsilon: 0.1,
+		arms:    make([]interface{}, val.Len()),
+		rnd:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		pulls:   make([]float64, val.Len()),
+		rewards: make([]float64, val.Len()),
+	}
+	indexes := make([]int, val.Len())
+	weights := make([]float64, val.Len())
+	for i := range b.arms {
+		b.arms[i] = val.Index(i).Interface()
+		indexes[i] = i
+		weights[i] = 1 / float64(len(b.arms))
+	}
+
+	g, err := discreteprobability.New(indexes, weights)
+	if err != nil {
+		return nil, err
+	}
+	b.explore = g
+	return b, nil
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (b *Bandit) SetSeed(s int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rnd = rand.New(rand.NewSource(s))
+	b.explore.SetSeed(s)
+}
+
+// Select returns the arm to play.
+func (b *Bandit) Select() interface{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.Strategy == Thompson {
+		return b.arms[b.thompson()]
+	}
+	if b.rnd.Float64() < b.Epsilon {
+		return b.arms[b.explore.RandomInt()]
+	}
+	return b.arms[b.greedy()]
+}
+
+// Reward records the reward r of playing arm.
+// It will return ErrArm if arm is unknown, or ErrReward if r is out of range [0, 1] for Thompson sampling.
+func (b *Bandit) Reward(arm interface{}, r float64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.Strategy == Thompson && (r < 0 || r > 1) {
+		return ErrReward
+	}
+	for i, a := range b.arms {
+		if reflect.DeepEqual(a, arm) {
+			b.pulls[i]++
+			b.rewards[i] += r
+			return nil
+		}
+	}
+	return ErrArm
+}
+
+// Mean returns the mean reward of arm, 0 if it has never been rewarded.
+func (b *Bandit) Mean(arm interface{}) (float64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, a := range b.arms {
+		if reflect.DeepEqual(a, arm) {
+			if b.pulls[i] == 0 {
+				return 0, nil
+			}
+			return b.rewards[i] / b.pulls[i], nil
+		}
+	}
+	return 0, ErrArm
+}
+
+// greedy returns the arm with the best mean reward, arms never rewarded come first.
+func (b *Bandit) greedy() int {
+	best, bestMean := 0, math.Inf(-1)
+	for i := range b.arms {
+		if b.pulls[i] == 0 {
+			return i
+		}
+		if mean := b.rewards[i] / b.pulls[i]; mean > bestMean {
+			best, bestMean = i, mean
+		}
+	}
+	return best
+}
+
+// thompson returns the arm with the best mean drawn from Beta(1+rewards, 1+failures).
+func (b *Bandit) thompson() int {
+	best, bestMean := 0, math.Inf(-1)
+	for i := range b.arms {
+		mean := b.beta(1+b.rewards[i], 1+b.pulls[i]-b.rewards[i])
+		if mean > bestMean {
+			best, bestMean = i, mean
+		}
+	}
+	return best
+}
+
+func (b *Bandit) beta(alpha, beta float64) float64 {
+	x := b.gamma(alpha)
+	y := b.gamma(beta)
+	return x / (x + y)
+}
+
+// gamma draws from Gamma(shape, 1) with the Marsaglia-Tsang method, shape >= 1.
+func (b *Bandit) gamma(shape float64) float64 {
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := b.rnd.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := b.rnd.Float64()
+		if math.Log(u) < 0.5*x*x+d-d*v+d*math.Log(v) {
+			return d * v
+		}
+	}
+}
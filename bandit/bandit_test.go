@@ -0,0 +1,66 @@
+package bandit
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+const rounds = 10000
+
+// play runs the bandit against arms with the given click rates and returns the pulls of each arm.
+func play(t *testing.T, b *Bandit, rates map[string]float64) map[interface{}]int {
+	rnd := rand.New(rand.NewSource(time.Now().Unix()))
+	pulls := map[interface{}]int{}
+	for i := 0; i < rounds; i++ {
+		arm := b.Select()
+		pulls[arm]++
+		r := float64(0)
+		if rnd.Float64() < rates[arm.(string)] {
+			r = 1
+		}
+		if err := b.Reward(arm, r); err != nil {
+			t.Errorf("Reward error %v", err)
+			t.FailNow()
+		}
+	}
+	return pulls
+}
+
+func TestEpsilonGreedy(t *testing.T) {
+	b, err := New([]string{"bad", "good"})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+	b.SetSeed(time.Now().Unix())
+
+	pulls := play(t, b, map[string]float64{"bad": 0.1, "good": 0.5})
+	if pulls["good"] < rounds*8/10 {
+		t.Errorf("expected the good arm to be exploited, got %v", pulls)
+	}
+	if pulls["bad"] == 0 {
+		t.Errorf("expected the bad arm to be explored, got %v", pulls)
+	}
+}
+
+func TestThompson(t *testing.T) {
+	b, _ := New([]string{"bad", "good"})
+	b.Strategy = Thompson
+	b.SetSeed(time.Now().Unix())
+
+	pulls := play(t, b, map[string]float64{"bad": 0.1, "good": 0.5})
+	if pulls["good"] < rounds*8/10 {
+		t.Errorf("expected the good arm to be exploited, got %v", pulls)
+	}
+
+	if err := b.Reward("good", 2); err != ErrReward {
+		t.Errorf("expected ErrReward, got %v", err)
+	}
+	if err := b.Reward("unknown", 1); err != ErrArm {
+		t.Errorf("expected ErrArm, got %v", err)
+	}
+	if mean, _ := b.Mean("good"); mean < 0.4 || mean > 0.6 {
+		t.Errorf("unexpected mean reward %v", mean)
+	}
+}
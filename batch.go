@@ -0,0 +1,66 @@
+package discreteprobability
+
+import "math/rand"
+
+// batchThreshold and batchMinSize are the batch size and the number of values from which
+// SampleN walks the CDF once instead of a binary search per draw, see BenchmarkSampleN.
+// With fewer values a binary search is only a couple of comparisons and wins.
+const (
+	batchThreshold = 64
+	batchMinSize   = 16
+)
+
+// SampleN returns n values drawn independently with the weights.
+// For n >= 64 and at least 16 values, the CDF is walked once with sorted uniforms
+// in O(n + m) instead of n binary searches in O(n log m).
+func (g *Generator) SampleN(n int) []interface{} {
+	if n <= 0 {
+		return nil
+	}
+	if g.tracer != nil {
+		defer g.traceBatch(n)
+	}
+	if n < batchThreshold || g.size < batchMinSize || g.exact != nil {
+		return g.sampleSearch(n)
+	}
+	return g.sampleWalk(n)
+}
+
+func (g *Generator) sampleSearch(n int) []interface{} {
+	samples := make([]interface{}, n)
+	for i := range samples {
+		j := g.pick(g.source)
+		g.observe(j, true)
+		samples[i] = g.values[j].Interface()
+	}
+	return samples
+}
+
+// sampleWalk generates n sorted uniforms directly from the normalized cumulative sums
+// of exponential spacings, walks the CDF with them, then shuffles the result.
+func (g *Generator) sampleWalk(n int) []interface{} {
+	r := rand.New(g.source)
+	spacings := make([]float64, n)
+	sum := float64(0)
+	for i := range spacings {
+		sum += r.ExpFloat64()
+		spacings[i] = sum
+	}
+	sum += r.ExpFloat64()
+
+	samples := make([]interface{}, n)
+	j := 0
+	for i, s := range spacings {
+		f := s / sum
+		for j < g.size-1 && g.weights[j] < f {
+			j++
+		}
+		samples[i] = g.values[j].Interface()
+		g.observe(j, true)
+	}
+
+	r.Shuffle(n, func(i, j int) {
+		samples[i], samples[j] = samples[j], samples[i]
+	})
+	return samples
+}
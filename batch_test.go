@@ -0,0 +1,66 @@
+package discreteprobability
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSampleN(t *testing.T) {
+	size := batchMinSize
+	g := generateInt(t, time.Now().Unix(), size)
+	for _, n := range []int{batchThreshold / 2, repeats} {
+		occurrence := map[int]float64{}
+		samples := g.SampleN(n)
+		if len(samples) != n {
+			t.Errorf("expected %v samples, got %v", n, len(samples))
+			t.FailNow()
+		}
+		for _, s := range samples {
+			occurrence[s.(int)]++
+		}
+		if n < repeats {
+			continue
+		}
+
+		p := float64(n) / float64(size)
+		for v := 0; v < size; v++ {
+			if d := p * 5 / 100; occurrence[v] > p+d || occurrence[v] < p-d {
+				t.Errorf("incorrect distribution value %v, expected %f, got %f", v, p, occurrence[v])
+			}
+		}
+	}
+}
+
+func TestSampleNShuffled(t *testing.T) {
+	g := generateInt(t, time.Now().Unix(), batchMinSize)
+	samples := g.SampleN(repeats)
+	// a sorted walk without the shuffle would change value at most batchMinSize-1 times
+	changes := 0
+	for i := 1; i < len(samples); i++ {
+		if samples[i] != samples[i-1] {
+			changes++
+		}
+	}
+	if changes < repeats/2 {
+		t.Errorf("samples are not shuffled, %v changes", changes)
+	}
+}
+
+func BenchmarkSampleN(b *testing.B) {
+	for _, size := range []int{4, 32, 1024} {
+		for _, n := range []int{16, 64, 256, 4096} {
+			g := generateInt(nil, 1, size)
+			b.Run(fmt.Sprintf("Search_size_%d_n_%d", size, n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					g.sampleSearch(n)
+				}
+			})
+			b.Run(fmt.Sprintf("Walk_size_%d_n_%d", size, n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					g.sampleWalk(n)
+				}
+			})
+		}
+	}
+}
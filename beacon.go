@@ -0,0 +1,96 @@
+package discreteprobability
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// BeaconSource is a rand.Source of the randomness of a public beacon, such as drand or the NIST
+// beacon, for the draws which need third-party entropy. A payload is fetched at most once per
+// TTL and expanded to a stream of SHA-256 blocks of the payload and a counter, which restarts
+// only when the payload changes. When a fetch fails, the last payload is kept until the next
+// fetch a TTL later, and before any payload the numbers come from a local source, with the
+// error returned by Err. It's safe for concurrent use.
+type BeaconSource struct {
+	// TTL is how long a payload is used before the next fetch, 30 seconds by default,
+	// which is the period of the drand mainnet
+	TTL time.Duration
+
+	mu       sync.Mutex
+	fetch    func() ([]byte, error)
+	payload  []byte
+	counter  uint64
+	fetched  time.Time
+	err      error
+	fallback rand.Source
+	now      func() time.Time
+}
+
+// NewBeaconSource returns a new BeaconSource of the payloads of fetch, e.g. the randomness of the
+// latest round of drand. Nothing is fetched until the first number is drawn.
+func NewBeaconSource(fetch func() ([]byte, error)) *BeaconSource {
+	return &BeaconSource{
+		TTL:      30 * time.Second,
+		fetch:    fetch,
+		fallback: rand.NewSource(time.Now().UnixNano()),
+		now:      time.Now,
+	}
+}
+
+// Seed seeds the local source of the fallback. The stream of the current payload goes on,
+// so the numbers already drawn from it are not repeated.
+func (b *BeaconSource) Seed(s int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.fallback.Seed(s)
+}
+
+// Int63 returns a non-negative pseudorandom 63-bit integer of the beacon stream.
+func (b *BeaconSource) Int63() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.maybeFetch()
+	if b.payload == nil {
+		return b.fallback.Int63()
+	}
+
+	var block [8]byte
+	binary.BigEndian.PutUint64(block[:], b.counter)
+	b.counter++
+	sum := sha256.Sum256(append(append([]byte(nil), b.payload...), block[:]...))
+	return int64(binary.BigEndian.Uint64(sum[:]) >> 1)
+}
+
+// maybeFetch fetches a payload if the last one is older than the TTL.
+func (b *BeaconSource) maybeFetch() {
+	now := b.now()
+	if !b.fetched.IsZero() && now.Sub(b.fetched) < b.TTL {
+		return
+	}
+	// a failed fetch is retried after the TTL too, so a beacon which is down is not flooded
+	b.fetched = now
+	payload, err := b.fetch()
+	if err == nil && len(payload) == 0 {
+		err = ErrShortOutput
+	}
+	b.err = err
+	if err != nil {
+		// the last payload is kept
+		return
+	}
+	// a beacon round which hasn't advanced gives the same payload, whose stream goes on
+	if !bytes.Equal(payload, b.payload) {
+		b.payload, b.counter = append([]byte(nil), payload...), 0
+	}
+}
+
+// Err returns the error of the last fetch, or nil if it succeeded.
+func (b *BeaconSource) Err() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.err
+}
@@ -0,0 +1,88 @@
+package discreteprobability
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBeaconSource(t *testing.T) {
+	payload := []byte("round 1")
+	var fail error
+	fetches := 0
+	b := NewBeaconSource(func() ([]byte, error) {
+		fetches++
+		return payload, fail
+	})
+	now := time.Unix(0, 0)
+	b.now = func() time.Time { return now }
+
+	first := []int64{b.Int63(), b.Int63()}
+	if fetches != 1 || first[0] == first[1] || first[0] < 0 {
+		t.Errorf("unexpected stream %v after %v fetches", first, fetches)
+	}
+	// the seed doesn't restart the stream of the payload
+	b.Seed(1)
+	if v := b.Int63(); v == first[0] || v == first[1] {
+		t.Errorf("expected a new number after the seed, got %v", v)
+	}
+
+	// a failed fetch keeps the last payload
+	now = now.Add(time.Minute)
+	fail = errors.New("unavailable")
+	if v := b.Int63(); v == first[0] || b.Err() != fail || fetches != 2 {
+		t.Errorf("expected the last payload and the error, got %v and %v", v, b.Err())
+	}
+
+	fail, payload = nil, []byte("round 2")
+	if b.Int63(); fetches != 2 {
+		t.Errorf("fetched again before the TTL")
+	}
+	now = now.Add(time.Minute)
+	if v := b.Int63(); v == first[1] || b.Err() != nil || fetches != 3 {
+		t.Errorf("expected a new payload, got %v and %v", v, b.Err())
+	}
+}
+
+func TestBeaconSourceSamePayload(t *testing.T) {
+	fetches := 0
+	b := NewBeaconSource(func() ([]byte, error) {
+		fetches++
+		return []byte("round 1"), nil
+	})
+	now := time.Unix(0, 0)
+	b.now = func() time.Time { return now }
+
+	// a round which hasn't advanced over several TTLs doesn't repeat the numbers
+	seen := map[int64]bool{}
+	for i := 0; i < 100; i++ {
+		if i%10 == 0 {
+			now = now.Add(time.Minute)
+		}
+		v := b.Int63()
+		if seen[v] {
+			t.Errorf("number %v repeated after %v fetches", v, fetches)
+			t.FailNow()
+		}
+		seen[v] = true
+	}
+	if fetches != 10 {
+		t.Errorf("expected 10 fetches, got %v", fetches)
+	}
+}
+
+func TestBeaconSourceGenerator(t *testing.T) {
+	fail := errors.New("unavailable")
+	b := NewBeaconSource(func() ([]byte, error) { return nil, fail })
+	g, _ := New([]int{1, 2}, []float64{0.5, 0.5})
+	g.SetSource(b)
+
+	// without any payload the draws come from the local source, and the fetch is retried after the TTL
+	count := map[int]float64{}
+	for i := 0; i < 10000; i++ {
+		count[g.RandomInt()]++
+	}
+	if count[1] < 4800 || count[1] > 5200 || b.Err() != fail {
+		t.Errorf("unexpected draws %v with the error %v", count, b.Err())
+	}
+}
@@ -0,0 +1,80 @@
+package discreteprobability
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// BootstrapResult is the distribution of a statistic over the bootstrap samples.
+type BootstrapResult struct {
+	// Stats are the statistic of every sample, in ascending order
+	Stats  []float64
+	Mean   float64
+	StdErr float64
+}
+
+// Interval returns the percentile confidence interval of the statistic at the level,
+// e.g. 0.95 for the 2.5% and 97.5% percentiles. It returns NaN if there are no stats.
+func (r BootstrapResult) Interval(level float64) (float64, float64) {
+	if len(r.Stats) == 0 {
+		return math.NaN(), math.NaN()
+	}
+	tail := (1 - level) / 2
+	return r.percentile(tail), r.percentile(1 - tail)
+}
+
+func (r BootstrapResult) percentile(p float64) float64 {
+	i := int(math.Round(p * float64(len(r.Stats)-1)))
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(r.Stats) {
+		i = len(r.Stats) - 1
+	}
+	return r.Stats[i]
+}
+
+// Bootstrap resamples the data with the weights iterations times, sampleSize values with
+// replacement each time, or the length of data if sampleSize is not positive, and returns
+// the distribution of the statistic over the samples. stat gets every sample as a slice of
+// the type of data, e.g. []float64 for []float64 data.
+// It will return error if data is not a slice, is empty, or data and weights don't match as in New
+func Bootstrap(data interface{}, weights []float64, iterations, sampleSize int, stat func(sample interface{}) float64) (BootstrapResult, error) {
+	return bootstrap(time.Now().UnixNano(), data, weights, iterations, sampleSize, stat)
+}
+
+// bootstrap is Bootstrap with the samples drawn from the seed.
+func bootstrap(seed int64, data interface{}, weights []float64, iterations, sampleSize int, stat func(sample interface{}) float64) (BootstrapResult, error) {
+	g, err := New(data, append([]float64(nil), weights...))
+	if err != nil {
+		return BootstrapResult{}, err
+	}
+	g.SetSeed(seed)
+	if sampleSize <= 0 {
+		sampleSize = g.size
+	}
+
+	sliceType := reflect.TypeOf(data)
+	result := BootstrapResult{Stats: make([]float64, 0, iterations)}
+	for n := 0; n < iterations; n++ {
+		sample := reflect.MakeSlice(sliceType, sampleSize, sampleSize)
+		for i := 0; i < sampleSize; i++ {
+			sample.Index(i).Set(g.random())
+		}
+		result.Stats = append(result.Stats, stat(sample.Interface()))
+	}
+	sort.Float64s(result.Stats)
+
+	for _, s := range result.Stats {
+		result.Mean += s / float64(len(result.Stats))
+	}
+	for _, s := range result.Stats {
+		result.StdErr += (s - result.Mean) * (s - result.Mean)
+	}
+	if len(result.Stats) > 1 {
+		result.StdErr = math.Sqrt(result.StdErr / float64(len(result.Stats)-1))
+	}
+	return result, nil
+}
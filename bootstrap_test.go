@@ -0,0 +1,50 @@
+package discreteprobability
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestBootstrap(t *testing.T) {
+	data := []float64{1, 2, 3, 4}
+	mean := func(sample interface{}) float64 {
+		sum := float64(0)
+		for _, v := range sample.([]float64) {
+			sum += v
+		}
+		return sum / float64(len(sample.([]float64)))
+	}
+
+	result, err := bootstrap(42, data, []float64{0.1, 0.2, 0.3, 0.4}, 1000, 100, mean)
+	if err != nil {
+		t.Errorf("Bootstrap error %v", err)
+		t.FailNow()
+	}
+	// the weighted mean is 3 with the standard error sqrt(1/100)
+	if len(result.Stats) != 1000 || math.Abs(result.Mean-3) > 0.05 || math.Abs(result.StdErr-0.1) > 0.02 {
+		t.Errorf("unexpected result mean %v stderr %v", result.Mean, result.StdErr)
+	}
+	lo, hi := result.Interval(0.95)
+	if !(lo < 3 && 3 < hi) || hi-lo > 0.5 {
+		t.Errorf("unexpected interval %v %v", lo, hi)
+	}
+	for i := 1; i < len(result.Stats); i++ {
+		if result.Stats[i] < result.Stats[i-1] {
+			t.Errorf("expected the stats in ascending order")
+			t.FailNow()
+		}
+	}
+
+	replay, _ := bootstrap(42, data, []float64{0.1, 0.2, 0.3, 0.4}, 1000, 100, mean)
+	if !reflect.DeepEqual(result, replay) {
+		t.Errorf("expected the same result with the same seed")
+	}
+
+	if _, err := Bootstrap(data, []float64{1}, 10, 10, mean); err != ErrLength {
+		t.Errorf("expected ErrLength, got %v", err)
+	}
+	if lo, hi := (BootstrapResult{}).Interval(0.95); !math.IsNaN(lo) || !math.IsNaN(hi) {
+		t.Errorf("expected NaN interval, got %v %v", lo, hi)
+	}
+}
@@ -0,0 +1,43 @@
+package discreteprobability
+
+import "sync"
+
+// scratch is the default pool of the scratch buffers of the batch and
+// without-replacement APIs.
+var scratch = &sync.Pool{}
+
+// WithBufferPool sets the pool of the scratch buffers used by the batch and
+// without-replacement APIs, such as Drain, SampleWithQuotas and RandomPair, and returns g.
+// The pool holds *[]bool values and its New can be nil. By default a package-level pool is used.
+func (g *Generator) WithBufferPool(pool *sync.Pool) *Generator {
+	g.buffers = pool
+	return g
+}
+
+// getPicked returns a zeroed buffer of n flags from the pool.
+func (g *Generator) getPicked(n int) *[]bool {
+	pool := g.buffers
+	if pool == nil {
+		pool = scratch
+	}
+
+	b, _ := pool.Get().(*[]bool)
+	if b == nil || cap(*b) < n {
+		buf := make([]bool, n)
+		return &buf
+	}
+	*b = (*b)[:n]
+	for i := range *b {
+		(*b)[i] = false
+	}
+	return b
+}
+
+// putPicked returns the buffer to the pool.
+func (g *Generator) putPicked(b *[]bool) {
+	pool := g.buffers
+	if pool == nil {
+		pool = scratch
+	}
+	pool.Put(b)
+}
@@ -0,0 +1,40 @@
+package discreteprobability
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithBufferPool(t *testing.T) {
+	pool := &sync.Pool{}
+	g := generateInt(t, time.Now().Unix(), sliceLen).WithBufferPool(pool)
+
+	for i := 0; i < sliceLen; i++ {
+		samples, rest := g.Drain(sliceLen / 2)
+		if len(samples) != sliceLen/2 || rest.size != sliceLen-sliceLen/2 {
+			t.Errorf("unexpected drain %v %v", samples, rest)
+			t.FailNow()
+		}
+	}
+
+	// a dirty buffer from the pool is cleared before use
+	dirty := make([]bool, sliceLen*2)
+	for i := range dirty {
+		dirty[i] = true
+	}
+	pool.Put(&dirty)
+	if _, _, err := g.RandomPair(); err != nil {
+		t.Errorf("RandomPair error %v", err)
+	}
+}
+
+func BenchmarkRandomPair(b *testing.B) {
+	g := generateInt(nil, 1, 32)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		g.RandomPair()
+	}
+}
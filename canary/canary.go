@@ -0,0 +1,211 @@
+// Package canary routes the requests of a reverse proxy to weighted upstreams, e.g. to send
+// a small share of the traffic to a canary release. The unhealthy upstreams get no traffic.
+// Example usage:
+//
+//		d, err := canary.NewWeightedDirector(map[string]float64{
+//			"http://stable:8080": 95,
+//			"http://canary:8080": 5,
+//		}, canary.WithHealthCheck(canary.HTTPCheck("/healthz"), 10*time.Second))
+//		if err != nil {
+//			panic(err) // Error handlers
+//		}
+//		defer d.Close()
+//		proxy := &httputil.ReverseProxy{Director: d.Direct}
+//
+//		5% of the requests go to the canary, and none while it fails its health check.
+package canary
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+// ErrUpstream is returned when an upstream is not an absolute URL
+var ErrUpstream = errors.New("upstream is not an absolute URL")
+
+// Option configures a Director.
+type Option func(*Director)
+
+// WithHealthCheck checks the health of every upstream with check every interval, from a
+// goroutine which stops on Close. An unhealthy upstream gets no traffic until it's healthy again.
+func WithHealthCheck(check func(upstream *url.URL) bool, interval time.Duration) Option {
+	return func(d *Director) {
+		d.check = check
+		d.interval = interval
+	}
+}
+
+// HTTPCheck returns a check which is healthy when a GET of the path of the upstream
+// responds with a 2xx status code within 5 seconds.
+func HTTPCheck(path string) func(upstream *url.URL) bool {
+	client := &http.Client{Timeout: 5 * time.Second}
+	return func(upstream *url.URL) bool {
+		resp, err := client.Get(upstream.JoinPath(path).String())
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode >= 200 && resp.StatusCode < 300
+	}
+}
+
+// Director picks an upstream for each request with the weights. It's safe for concurrent use.
+type Director struct {
+	mu        sync.Mutex
+	upstreams []*url.URL
+	weights   []float64
+	healthy   []bool
+	g         *discreteprobability.Generator
+	rnd       *rand.Rand
+
+	check    func(upstream *url.URL) bool
+	interval time.Duration
+	done     chan struct{}
+	once     sync.Once
+}
+
+// NewWeightedDirector returns a new Director over the upstreams, which are URLs with their
+// relative weights, e.g. percentages. It will return ErrUpstream if an upstream is not an
+// absolute URL, or the error of discreteprobability.NewNormalized if the weights are invalid
+func NewWeightedDirector(upstreams map[string]float64, opts ...Option) (*Director, error) {
+	raw := make([]string, 0, len(upstreams))
+	for u := range upstreams {
+		raw = append(raw, u)
+	}
+	// map iteration order is random, sort the upstreams so a seeded director is reproducible
+	sort.Strings(raw)
+
+	d := &Director{
+		upstreams: make([]*url.URL, len(raw)),
+		weights:   make([]float64, len(raw)),
+		healthy:   make([]bool, len(raw)),
+		rnd:       rand.New(rand.NewSource(time.Now().UnixNano())),
+		done:      make(chan struct{}),
+	}
+	for i, u := range raw {
+		parsed, err := url.Parse(u)
+		if err != nil || !parsed.IsAbs() || parsed.Host == "" {
+			return nil, ErrUpstream
+		}
+		d.upstreams[i] = parsed
+		d.weights[i] = upstreams[u]
+		d.healthy[i] = true
+	}
+	if err := d.rebuild(); err != nil {
+		return nil, err
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+	if d.check != nil && d.interval > 0 {
+		go d.watch()
+	}
+	return d, nil
+}
+
+// rebuild draws with the weights of the healthy upstreams. If none is healthy, all of them
+// are drawn with their weights, as it's better to try than to fail every request.
+func (d *Director) rebuild() error {
+	weights := make([]float64, len(d.weights))
+	sum := float64(0)
+	for i, w := range d.weights {
+		if d.healthy[i] {
+			weights[i] = w
+			sum += w
+		}
+	}
+	if sum <= 0 {
+		copy(weights, d.weights)
+	}
+	g, err := discreteprobability.NewNormalized(d.upstreams, weights)
+	if err != nil {
+		return err
+	}
+	g.SetSeed(d.rnd.Int63())
+	d.g = g
+	return nil
+}
+
+// watch checks the health of the upstreams every interval until Close.
+func (d *Director) watch() {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.done:
+			return
+		case <-ticker.C:
+		}
+		for _, u := range d.upstreams {
+			d.SetHealthy(u.String(), d.check(u))
+		}
+	}
+}
+
+// Close stops the health checks.
+func (d *Director) Close() {
+	d.once.Do(func() { close(d.done) })
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (d *Director) SetSeed(s int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.rnd = rand.New(rand.NewSource(s))
+	d.g.SetSeed(d.rnd.Int63())
+}
+
+// SetHealthy marks the upstream as healthy or not, e.g. from an external health checker.
+// An unknown upstream is ignored.
+func (d *Director) SetHealthy(upstream string, healthy bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i, u := range d.upstreams {
+		if u.String() == upstream && d.healthy[i] != healthy {
+			d.healthy[i] = healthy
+			// the weights were valid when the director was created, so they still are
+			d.rebuild()
+		}
+	}
+}
+
+// Pick returns the upstream of the next request.
+func (d *Director) Pick() *url.URL {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.g.RandomInterface().(*url.URL)
+}
+
+// Direct rewrites the request to an upstream drawn with the weights, as the Director of
+// httputil.NewSingleHostReverseProxy does for a single upstream.
+func (d *Director) Direct(r *http.Request) {
+	target := d.Pick()
+	r.URL.Scheme = target.Scheme
+	r.URL.Host = target.Host
+	r.URL.Path = joinPath(target.Path, r.URL.Path)
+	if target.RawQuery == "" || r.URL.RawQuery == "" {
+		r.URL.RawQuery = target.RawQuery + r.URL.RawQuery
+	} else {
+		r.URL.RawQuery = target.RawQuery + "&" + r.URL.RawQuery
+	}
+}
+
+// joinPath joins the paths with a single slash.
+func joinPath(a, b string) string {
+	switch aslash, bslash := strings.HasSuffix(a, "/"), strings.HasPrefix(b, "/"); {
+	case aslash && bslash:
+		return a + b[1:]
+	case !aslash && !bslash && a != "" && b != "":
+		return a + "/" + b
+	}
+	return a + b
+}
@@ -0,0 +1,94 @@
+package canary
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestDirector(t *testing.T) {
+	d, err := NewWeightedDirector(map[string]float64{
+		"http://stable:8080":    90,
+		"http://canary:8080/v2": 10,
+	})
+	if err != nil {
+		t.Errorf("NewWeightedDirector error %v", err)
+		t.FailNow()
+	}
+	d.SetSeed(1)
+
+	hosts := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		r := httptest.NewRequest("GET", "/api?x=1", nil)
+		d.Direct(r)
+		hosts[r.URL.Host]++
+		if r.URL.Host == "canary:8080" && r.URL.Path != "/v2/api" {
+			t.Errorf("unexpected path %v", r.URL.Path)
+			t.FailNow()
+		}
+		if r.URL.RawQuery != "x=1" {
+			t.Errorf("unexpected query %v", r.URL.RawQuery)
+			t.FailNow()
+		}
+	}
+	if n := hosts["canary:8080"]; n < 900 || n > 1100 {
+		t.Errorf("expected about 1000 canary requests, got %v", n)
+	}
+
+	d.SetHealthy("http://canary:8080/v2", false)
+	for i := 0; i < 1000; i++ {
+		if u := d.Pick(); u.Host != "stable:8080" {
+			t.Errorf("unhealthy upstream picked %v", u)
+			t.FailNow()
+		}
+	}
+
+	// with no healthy upstream, the weights are used
+	d.SetHealthy("http://stable:8080", false)
+	hosts = map[string]int{}
+	for i := 0; i < 1000; i++ {
+		hosts[d.Pick().Host]++
+	}
+	if hosts["canary:8080"] == 0 || hosts["stable:8080"] == 0 {
+		t.Errorf("expected both upstreams, got %v", hosts)
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer healthy.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	d, err := NewWeightedDirector(map[string]float64{healthy.URL: 50, failing.URL: 50},
+		WithHealthCheck(HTTPCheck("/healthz"), time.Millisecond))
+	if err != nil {
+		t.Errorf("NewWeightedDirector error %v", err)
+		t.FailNow()
+	}
+	defer d.Close()
+
+	want, _ := url.Parse(healthy.URL)
+	deadline := time.Now().Add(5 * time.Second)
+	for n := 0; n < 100; {
+		if time.Now().After(deadline) {
+			t.Errorf("failing upstream still picked")
+			t.FailNow()
+		}
+		if d.Pick().Host == want.Host {
+			n++
+		} else {
+			n = 0
+		}
+	}
+}
+
+func TestNewWeightedDirectorErrors(t *testing.T) {
+	if _, err := NewWeightedDirector(map[string]float64{"stable:8080": 1}); err != ErrUpstream {
+		t.Errorf("expected ErrUpstream, got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package discreteprobability
+
+import (
+	"math/rand"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// CapacityAware draws values with probabilities proportional to their live capacities,
+// e.g. the remaining quota per backend. The capacities are read at draw time and cached
+// for an interval. It's safe for concurrent use.
+type CapacityAware struct {
+	mu       sync.Mutex
+	values   []reflect.Value
+	capacity func(v interface{}) float64
+	interval time.Duration
+	g        *Generator
+	read     time.Time
+	rnd      *rand.Rand
+	now      func() time.Time
+}
+
+// NewCapacityAware returns a new CapacityAware over the values, which should be a slice.
+// The capacities are read again by capacity once they are older than interval, or on every
+// draw if interval is not positive. A negative capacity counts as zero.
+// It will return error if values is not a slice or is empty
+func NewCapacityAware(v interface{}, capacity func(v interface{}) float64, interval time.Duration) (*CapacityAware, error) {
+	values, err := sliceValues(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, ErrEmptyInput
+	}
+
+	return &CapacityAware{
+		values:   values,
+		capacity: capacity,
+		interval: interval,
+		rnd:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		now:      time.Now,
+	}, nil
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (c *CapacityAware) SetSeed(s int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rnd = rand.New(rand.NewSource(s))
+	if c.g != nil {
+		c.g.SetSeed(c.rnd.Int63())
+	}
+}
+
+// Random returns a value drawn with the capacities.
+// It will return ErrNotEnough if none of the values has a positive capacity
+func (c *CapacityAware) Random() (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if c.g == nil || c.interval <= 0 || now.Sub(c.read) >= c.interval {
+		c.read = now
+		weights := make([]float64, len(c.values))
+		for i, value := range c.values {
+			if w := c.capacity(value.Interface()); w > 0 {
+				weights[i] = w
+			}
+		}
+		g, err := newCapacityGenerator(c.values, weights)
+		if err != nil {
+			c.g = nil
+			return nil, err
+		}
+		g.SetSeed(c.rnd.Int63())
+		c.g = g
+	}
+	return c.g.RandomInterface(), nil
+}
+
+// newCapacityGenerator returns a Generator over the values with the normalized capacities.
+func newCapacityGenerator(values []reflect.Value, capacities []float64) (*Generator, error) {
+	weights, err := normalize(capacities)
+	if err != nil {
+		return nil, ErrNotEnough
+	}
+	return newGenerator(copyValues(values), weights)
+}
@@ -0,0 +1,61 @@
+package discreteprobability
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCapacityAware(t *testing.T) {
+	capacities := map[string]float64{"a": 1, "b": 3}
+	reads := 0
+	c, err := NewCapacityAware([]string{"a", "b"}, func(v interface{}) float64 {
+		reads++
+		return capacities[v.(string)]
+	}, time.Minute)
+	if err != nil {
+		t.Errorf("NewCapacityAware error %v", err)
+		t.FailNow()
+	}
+	c.SetSeed(time.Now().Unix())
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	count := 0
+	for i := 0; i < repeats; i++ {
+		v, err := c.Random()
+		if err != nil {
+			t.Errorf("Random error %v", err)
+			t.FailNow()
+		}
+		if v == "b" {
+			count++
+		}
+	}
+	p := float64(repeats) * 0.75
+	if d := p * 3 / 100; float64(count) > p+d || float64(count) < p-d {
+		t.Errorf("incorrect distribution, expected %f, got %d", p, count)
+	}
+	if reads != 2 {
+		t.Errorf("expected the capacities read once, got %v reads", reads)
+	}
+
+	// b runs out of capacity, which is seen after the interval
+	capacities["b"] = -1
+	now = now.Add(time.Minute)
+	for i := 0; i < 100; i++ {
+		if v, _ := c.Random(); v != "a" {
+			t.Errorf("expected a, got %v", v)
+			t.FailNow()
+		}
+	}
+
+	capacities["a"] = 0
+	now = now.Add(time.Minute)
+	if _, err := c.Random(); err != ErrNotEnough {
+		t.Errorf("expected ErrNotEnough, got %v", err)
+	}
+
+	if _, err := NewCapacityAware([]string{}, nil, 0); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+}
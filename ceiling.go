@@ -0,0 +1,34 @@
+package discreteprobability
+
+// WithCeiling returns a new Generator in which no value has a probability above pmax,
+// e.g. for the fairness of a traffic allocation. The values above pmax are capped to it
+// and the excess is redistributed to the others proportionally to their weights.
+// The random stream of the new Generator is seeded from g.
+// It will return ErrProbability if pmax is larger than 1 or smaller than 1/n for n values,
+// or ErrNotEnough if the values under pmax have no weight to take the excess
+func (g *Generator) WithCeiling(pmax float64) (*Generator, error) {
+	if !(pmax <= 1 && pmax*float64(g.size) >= 1) {
+		return nil, ErrProbability
+	}
+
+	masses, bounds := make([]float64, g.size), make([]float64, g.size)
+	for i := range masses {
+		masses[i], bounds[i] = g.probability(i), pmax
+	}
+	capped, scale, err := pin(masses, bounds, true)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make([]float64, g.size)
+	for i, c := range capped {
+		weights[i] = g.probability(i) * scale
+		if c {
+			weights[i] = pmax
+		}
+	}
+	if weights, err = normalize(weights); err != nil {
+		return nil, err
+	}
+	return g.derive(weights)
+}
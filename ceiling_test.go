@@ -0,0 +1,55 @@
+package discreteprobability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWithCeiling(t *testing.T) {
+	g, err := New([]string{"a", "b", "c", "d"}, []float64{0.05, 0.15, 0.2, 0.6})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+
+	c, err := g.WithCeiling(0.4)
+	if err != nil {
+		t.Errorf("WithCeiling error %v", err)
+		t.FailNow()
+	}
+	// the excess 0.2 of d is shared in the ratio 0.05:0.15:0.2
+	if !weightEqual(c, "a", 0.075) || !weightEqual(c, "b", 0.225) ||
+		!weightEqual(c, "c", 0.3) || !weightEqual(c, "d", 0.4) {
+		t.Errorf("unexpected generator %v", c)
+	}
+
+	// capping d pushes c above the ceiling too
+	c, err = g.WithCeiling(0.32)
+	if err != nil {
+		t.Errorf("WithCeiling error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(c, "a", 0.09) || !weightEqual(c, "b", 0.27) ||
+		!weightEqual(c, "c", 0.32) || !weightEqual(c, "d", 0.32) {
+		t.Errorf("unexpected generator %v", c)
+	}
+
+	if _, err := g.WithCeiling(0.2); err != ErrProbability {
+		t.Errorf("expected ErrProbability, got %v", err)
+	}
+	if _, err := g.WithCeiling(1.5); err != ErrProbability {
+		t.Errorf("expected ErrProbability, got %v", err)
+	}
+	if _, err := g.WithCeiling(math.NaN()); err != ErrProbability {
+		t.Errorf("expected ErrProbability for NaN, got %v", err)
+	}
+
+	g, err = New([]string{"a", "b"}, []float64{0, 1})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+	if _, err := g.WithCeiling(0.5); err != ErrNotEnough {
+		t.Errorf("expected ErrNotEnough, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+// Package cem is the cross-entropy method over a discreteprobability.Generator as the proposal
+// distribution: every iteration draws samples, keeps the best scoring ones as the elites and
+// refits the weights to them, so the generator concentrates on the values with high scores.
+// Example usage:
+//
+//		g, _ := discreteprobability.New([]int{1, 2, 3, 4}, []float64{0.25, 0.25, 0.25, 0.25})
+//		best, err := cem.Optimize(g, func(v interface{}) float64 {
+//			return -math.Abs(float64(v.(int)) - 3)
+//		}, cem.Options{})
+//		if err != nil {
+//			panic(err) // Error handlers
+//		}
+//		num := best.RandomInt()
+package cem
+
+import (
+	"sort"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+// Options are the parameters of Optimize. The zero value of a field is its default.
+type Options struct {
+	// Iterations is the number of refits, 10 by default
+	Iterations int
+	// Samples is the number of values drawn every iteration, 100 by default
+	Samples int
+	// Elite is the fraction of the samples kept as the elites, 0.1 by default
+	Elite float64
+	// Smoothing is the share of the current weights kept on every refit, 0 by default
+	Smoothing float64
+}
+
+func (o Options) withDefaults() Options {
+	if o.Iterations <= 0 {
+		o.Iterations = 10
+	}
+	if o.Samples <= 0 {
+		o.Samples = 100
+	}
+	if o.Elite <= 0 || o.Elite > 1 {
+		o.Elite = 0.1
+	}
+	return o
+}
+
+// Elites returns the count best scoring samples, the highest score first.
+func Elites(samples []interface{}, score func(v interface{}) float64, count int) []interface{} {
+	scores := make([]float64, len(samples))
+	order := make([]int, len(samples))
+	for i, sample := range samples {
+		scores[i] = score(sample)
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] > scores[order[j]]
+	})
+
+	if count > len(samples) {
+		count = len(samples)
+	}
+	elites := make([]interface{}, count)
+	for i := range elites {
+		elites[i] = samples[order[i]]
+	}
+	return elites
+}
+
+// Optimize runs the cross-entropy method from g and returns the refitted generator.
+// g is not changed. It will return the error of Refit, e.g. if smoothing is out of range [0, 1]
+func Optimize(g *discreteprobability.Generator, score func(v interface{}) float64, opts Options) (*discreteprobability.Generator, error) {
+	opts = opts.withDefaults()
+	count := int(float64(opts.Samples) * opts.Elite)
+	if count < 1 {
+		count = 1
+	}
+
+	for i := 0; i < opts.Iterations; i++ {
+		elites := Elites(g.SampleN(opts.Samples), score, count)
+		refitted, err := g.Refit(elites, opts.Smoothing)
+		if err != nil {
+			return nil, err
+		}
+		g = refitted
+	}
+	return g, nil
+}
@@ -0,0 +1,49 @@
+package cem
+
+import (
+	"math"
+	"testing"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+func TestOptimize(t *testing.T) {
+	values := make([]int, 20)
+	weights := make([]float64, 20)
+	for i := range values {
+		values[i] = i
+		weights[i] = 0.05
+	}
+	g, _ := discreteprobability.New(values, weights)
+	score := func(v interface{}) float64 {
+		return -math.Abs(float64(v.(int)) - 13)
+	}
+
+	best, err := Optimize(g, score, Options{Smoothing: 0.3})
+	if err != nil {
+		t.Errorf("Optimize error %v", err)
+		t.FailNow()
+	}
+	count := 0
+	for i := 0; i < 1000; i++ {
+		if best.RandomInt() == 13 {
+			count++
+		}
+	}
+	if count < 900 {
+		t.Errorf("expected the generator concentrated on 13, got %v of 1000", count)
+	}
+
+	if _, err := Optimize(g, score, Options{Smoothing: 2}); err != discreteprobability.ErrProbability {
+		t.Errorf("expected ErrProbability, got %v", err)
+	}
+}
+
+func TestElites(t *testing.T) {
+	elites := Elites([]interface{}{3, 1, 4, 1, 5}, func(v interface{}) float64 {
+		return float64(v.(int))
+	}, 2)
+	if len(elites) != 2 || elites[0] != 5 || elites[1] != 4 {
+		t.Errorf("unexpected elites %v", elites)
+	}
+}
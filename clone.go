@@ -0,0 +1,34 @@
+package discreteprobability
+
+import "math/rand"
+
+// Clone returns a copy of the Generator with a fresh random stream.
+// The seed of the copy is drawn from g, so cloning a seeded generator is reproducible.
+func (g *Generator) Clone() *Generator {
+	return g.CloneWithSeed(g.source.Int63())
+}
+
+// CloneWithSeed returns a copy of the Generator which uses the seed s.
+func (g *Generator) CloneWithSeed(s int64) *Generator {
+	c := *g
+	c.values = copyValues(g.values)
+	c.weights = append([]float64(nil), g.weights...)
+	c.source = rand.NewSource(s)
+	c.tickSeed = s
+	// the copy counts its own draws for the logger
+	if g.logger != nil {
+		c.logger = &drawLogger{logger: g.logger.logger, rate: g.logger.rate}
+	}
+	return &c
+}
+
+// derive returns a new Generator over the values of g with the new weights, which are
+// in the order of g. The random stream of the new Generator is seeded from g.
+func (g *Generator) derive(weights []float64) (*Generator, error) {
+	d, err := newGenerator(copyValues(g.values), weights)
+	if err != nil {
+		return nil, err
+	}
+	d.SetSeed(g.source.Int63())
+	return d, nil
+}
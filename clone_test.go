@@ -0,0 +1,40 @@
+package discreteprobability
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCloneWithSeed(t *testing.T) {
+	g := generateInt(t, time.Now().Unix(), sliceLen)
+	first := g.CloneWithSeed(0)
+	second := g.CloneWithSeed(0)
+
+	for i := 0; i < repeats; i++ {
+		a, b := first.RandomInt(), second.RandomInt()
+		if a != b {
+			t.Errorf("position %v got different result %v and %v", i, a, b)
+			t.FailNow()
+		}
+	}
+}
+
+func TestClone(t *testing.T) {
+	g := generateInt(t, 0, sliceLen)
+	c := g.Clone()
+	c.weights[0] = 1
+
+	if g.weights[0] == 1 {
+		t.Errorf("clone shares the weights with the original generator")
+	}
+
+	same := true
+	for i := 0; i < sliceLen; i++ {
+		if g.RandomInt() != c.RandomInt() {
+			same = false
+		}
+	}
+	if same {
+		t.Errorf("clone has the same random stream as the original generator")
+	}
+}
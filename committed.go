@@ -0,0 +1,67 @@
+package discreteprobability
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"reflect"
+)
+
+// Commit returns the commitment of a secret seed, its SHA-256 hash, which is published before
+// the draws so the seed can't be changed afterwards.
+func Commit(secretSeed []byte) []byte {
+	h := sha256.Sum256(secretSeed)
+	return h[:]
+}
+
+// CommittedDraw returns a provably fair draw, e.g. of a raffle: the value is drawn with the
+// HMAC-SHA256 of the public nonce keyed by the secret seed, which is the proof. Once the seed
+// is revealed, anyone can check with Verify that the value was derived from the committed seed
+// and the nonce, e.g. a hash of the list of the entrants. The random stream of the generator
+// is not touched.
+func (g *Generator) CommittedDraw(secretSeed []byte, publicNonce []byte) (interface{}, []byte) {
+	proof := committedProof(secretSeed, publicNonce)
+	i := g.committedIndex(proof)
+	g.observe(i, false)
+	return g.values[i].Interface(), proof
+}
+
+// Verify checks a committed draw, with a generator of the same values and weights as the one of the draw,
+// in any order. Values of kinds other than numbers and strings must be in the same order as for the draw.
+// It will return ErrProof if the seed doesn't match the commitment, the proof doesn't match the seed
+// and the nonce, or the value is not the one of the proof
+func (g *Generator) Verify(commitment, secretSeed, publicNonce, proof []byte, value interface{}) error {
+	if !hmac.Equal(Commit(secretSeed), commitment) {
+		return ErrProof
+	}
+	if !hmac.Equal(committedProof(secretSeed, publicNonce), proof) {
+		return ErrProof
+	}
+	if !reflect.DeepEqual(g.values[g.committedIndex(proof)].Interface(), value) {
+		return ErrProof
+	}
+	return nil
+}
+
+// UniformFromBytes maps random bytes, e.g. a hash or the output of a VRF, to a uniform in [0, 1)
+// with their first 53 bits, for RandomFromUniform.
+// It will return ErrShortOutput if there are fewer than 8 bytes
+func UniformFromBytes(b []byte) (float64, error) {
+	if len(b) < 8 {
+		return 0, ErrShortOutput
+	}
+	return float64(binary.BigEndian.Uint64(b)>>11) / (1 << 53), nil
+}
+
+func committedProof(secretSeed, publicNonce []byte) []byte {
+	mac := hmac.New(sha256.New, secretSeed)
+	mac.Write(publicNonce)
+	return mac.Sum(nil)
+}
+
+// committedIndex returns the index drawn with the uniform of the proof, which is a SHA-256 sum.
+// The uniform is mapped in ascending order of the values, which is the same for any order of the input.
+func (g *Generator) committedIndex(proof []byte) int {
+	u, _ := UniformFromBytes(proof)
+	return g.uniformIndex(u)
+}
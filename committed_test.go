@@ -0,0 +1,72 @@
+package discreteprobability
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCommittedDraw(t *testing.T) {
+	g, _ := New([]string{"alice", "bob", "carol"}, []float64{0.5, 0.25, 0.25})
+	secret := []byte("operator secret")
+	commitment := Commit(secret)
+
+	count := map[interface{}]float64{}
+	for i := 0; i < repeats; i++ {
+		nonce := []byte(fmt.Sprint("round", i))
+		v, proof := g.CommittedDraw(secret, nonce)
+		if again, _ := g.CommittedDraw(secret, nonce); again != v {
+			t.Errorf("draw %v not reproducible: %v and %v", i, v, again)
+			t.FailNow()
+		}
+		if err := g.Verify(commitment, secret, nonce, proof, v); err != nil {
+			t.Errorf("Verify error %v", err)
+			t.FailNow()
+		}
+		count[v]++
+	}
+	for v, w := range map[string]float64{"alice": 0.5, "bob": 0.25, "carol": 0.25} {
+		p := w * repeats
+		if d := p * 3 / 100; count[v] > p+d || count[v] < p-d {
+			t.Errorf("incorrect distribution value %v, expected %f, got %f", v, p, count[v])
+		}
+	}
+}
+
+func TestVerifyErrors(t *testing.T) {
+	g, _ := New([]string{"alice", "bob"}, []float64{0.5, 0.5})
+	secret, nonce := []byte("secret"), []byte("nonce")
+	commitment := Commit(secret)
+	v, proof := g.CommittedDraw(secret, nonce)
+	other := map[interface{}]string{"alice": "bob", "bob": "alice"}[v]
+
+	if err := g.Verify(commitment, []byte("other secret"), nonce, proof, v); err != ErrProof {
+		t.Errorf("expected ErrProof for another seed, got %v", err)
+	}
+	if err := g.Verify(commitment, secret, []byte("other nonce"), proof, v); err != ErrProof {
+		t.Errorf("expected ErrProof for another nonce, got %v", err)
+	}
+	if err := g.Verify(commitment, secret, nonce, proof, other); err != ErrProof {
+		t.Errorf("expected ErrProof for another value, got %v", err)
+	}
+}
+
+func TestVerifyShuffled(t *testing.T) {
+	values, reversed := make([]int, 50), make([]int, 50)
+	weights := make([]float64, 50)
+	for i := range values {
+		values[i], reversed[len(reversed)-1-i] = i, i
+		weights[i] = 1.0 / 50
+	}
+	// New accumulates the weights in place
+	g, _ := New(values, append([]float64(nil), weights...))
+	verifier, _ := New(reversed, weights)
+	secret := []byte("operator secret")
+	for i := 0; i < 100; i++ {
+		nonce := []byte(fmt.Sprint("round", i))
+		v, proof := g.CommittedDraw(secret, nonce)
+		if err := verifier.Verify(Commit(secret), secret, nonce, proof, v); err != nil {
+			t.Errorf("Verify error %v for round %v", err, i)
+			t.FailNow()
+		}
+	}
+}
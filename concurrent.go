@@ -0,0 +1,85 @@
+package discreteprobability
+
+import (
+	"math/rand"
+	randv2 "math/rand/v2"
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+// Locked is a Generator guarded by a mutex, which is safe for concurrent use.
+type Locked struct {
+	mu sync.Mutex
+	g  *Generator
+}
+
+// Locked returns a copy of the generator guarded by a mutex. The random stream of
+// the copy is seeded from g.
+func (g *Generator) Locked() *Locked {
+	return &Locked{g: g.Clone()}
+}
+
+func (l *Locked) random() reflect.Value {
+	l.mu.Lock()
+	v := l.g.random()
+	l.mu.Unlock()
+	return v
+}
+
+// Random returns the value from the value set with corresponding weights.
+func (l *Locked) Random() interface{} {
+	return l.random().Interface()
+}
+
+// RandomInt returns the int value without type assertion, see Generator.RandomInt
+func (l *Locked) RandomInt() int {
+	return int(l.random().Int())
+}
+
+// shard is a random source of Sharded, padded to its own cache line.
+type shard struct {
+	mu     sync.Mutex
+	source rand.Source
+	_      [64]byte
+}
+
+// Sharded is a Generator with a random source per processor, which is safe for
+// concurrent use. A draw locks one of GOMAXPROCS sources picked by the per-thread
+// runtime random number, so the contention stays low on many-core machines.
+type Sharded struct {
+	g      *Generator
+	shards []shard
+}
+
+// Sharded returns a copy of the generator with GOMAXPROCS random sources,
+// each seeded from g.
+func (g *Generator) Sharded() *Sharded {
+	s := &Sharded{
+		g:      g.CloneWithSeed(0),
+		shards: make([]shard, runtime.GOMAXPROCS(0)),
+	}
+	for i := range s.shards {
+		s.shards[i].source = rand.NewSource(g.source.Int63())
+	}
+	return s
+}
+
+func (s *Sharded) random() reflect.Value {
+	sh := &s.shards[randv2.Uint32N(uint32(len(s.shards)))]
+	sh.mu.Lock()
+	i := s.g.pick(sh.source)
+	sh.mu.Unlock()
+	s.g.observe(i, false)
+	return s.g.values[i]
+}
+
+// Random returns the value from the value set with corresponding weights.
+func (s *Sharded) Random() interface{} {
+	return s.random().Interface()
+}
+
+// RandomInt returns the int value without type assertion, see Generator.RandomInt
+func (s *Sharded) RandomInt() int {
+	return int(s.random().Int())
+}
@@ -0,0 +1,70 @@
+package discreteprobability
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSharded(t *testing.T) {
+	s := generateInt(t, time.Now().Unix(), sliceLen).Sharded()
+	l := generateInt(t, time.Now().Unix(), sliceLen).Locked()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	occurrence := map[int]float64{}
+	for n := 0; n < 8; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			local := map[int]float64{}
+			for i := 0; i < repeats/8; i++ {
+				local[s.RandomInt()]++
+				l.RandomInt()
+			}
+			mu.Lock()
+			for v, count := range local {
+				occurrence[v] += count
+			}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	p := float64(repeats/8*8) / sliceLen
+	for v := 0; v < sliceLen; v++ {
+		if d := p * 5 / 100; occurrence[v] > p+d || occurrence[v] < p-d {
+			t.Errorf("incorrect distribution value %v, expected %f, got %f", v, p, occurrence[v])
+		}
+	}
+}
+
+func BenchmarkContention(b *testing.B) {
+	b.Run("Locked", func(b *testing.B) {
+		l := generateInt(nil, 1, 16).Locked()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				l.RandomInt()
+			}
+		})
+	})
+	b.Run("Sharded", func(b *testing.B) {
+		s := generateInt(nil, 1, 16).Sharded()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				s.RandomInt()
+			}
+		})
+	})
+	b.Run("Frozen", func(b *testing.B) {
+		f := generateInt(nil, 1, 16).Freeze()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				f.RandomInt()
+			}
+		})
+	})
+}
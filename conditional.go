@@ -0,0 +1,65 @@
+package discreteprobability
+
+import (
+	"math/rand"
+	"reflect"
+)
+
+var generatorType = reflect.TypeOf((*Generator)(nil))
+
+// Conditional stores a Generator for each condition to model P(X|Y).
+// All the draws share a single random source, so one seed makes the
+// whole table reproducible.
+type Conditional struct {
+	tables reflect.Value
+	source rand.Source
+}
+
+// NewConditional returns a new Conditional. The tables should be a map
+// from the condition to the Generator, e.g. map[string]*Generator.
+// It will return error if tables is not such a map or any of the generators is nil.
+func NewConditional(tables interface{}) (*Conditional, error) {
+	val := reflect.ValueOf(tables)
+	if val.Kind() != reflect.Map {
+		return nil, ErrNotMap
+	}
+	if val.Type().Elem() != generatorType {
+		return nil, ErrType
+	}
+
+	iter := val.MapRange()
+	for iter.Next() {
+		if iter.Value().IsNil() {
+			return nil, ErrCondition
+		}
+	}
+
+	return &Conditional{
+		tables: val,
+		source: rand.NewSource(seed),
+	}, nil
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (c *Conditional) SetSeed(s int64) {
+	c.source = rand.NewSource(s)
+}
+
+// RandomGiven returns a value drawn from the generator of condition k.
+// It will return ErrCondition if k is not in the table.
+func (c *Conditional) RandomGiven(k interface{}) (interface{}, error) {
+	key := reflect.ValueOf(k)
+	if !key.IsValid() || !key.Type().AssignableTo(c.tables.Type().Key()) {
+		return nil, ErrType
+	}
+
+	v := c.tables.MapIndex(key)
+	if !v.IsValid() {
+		return nil, ErrCondition
+	}
+
+	g := v.Interface().(*Generator)
+	i := g.pick(c.source)
+	g.observe(i, false)
+	return g.values[i].Interface(), nil
+}
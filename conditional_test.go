@@ -0,0 +1,75 @@
+package discreteprobability
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConditional(t *testing.T) {
+	c, err := NewConditional(map[string]*Generator{
+		"int":    generateInt(t, time.Now().Unix(), sliceLen),
+		"string": generateString(t, time.Now().Unix(), sliceLen),
+	})
+	if err != nil {
+		t.Errorf("NewConditional error %v", err)
+		t.FailNow()
+	}
+
+	v, err := c.RandomGiven("int")
+	if err != nil {
+		t.Errorf("RandomGiven error %v", err)
+		t.FailNow()
+	}
+	if _, ok := v.(int); !ok {
+		t.Errorf("expected int value, got %v", v)
+	}
+
+	v, err = c.RandomGiven("string")
+	if err != nil {
+		t.Errorf("RandomGiven error %v", err)
+		t.FailNow()
+	}
+	if _, ok := v.(string); !ok {
+		t.Errorf("expected string value, got %v", v)
+	}
+
+	if _, err := c.RandomGiven("float64"); err != ErrCondition {
+		t.Errorf("expected ErrCondition, got %v", err)
+	}
+	if _, err := c.RandomGiven(1); err != ErrType {
+		t.Errorf("expected ErrType, got %v", err)
+	}
+}
+
+func TestConditionalSeeding(t *testing.T) {
+	tables := map[int]*Generator{
+		1: generateInt(t, 1, sliceLen),
+		2: generateInt(t, 2, sliceLen),
+	}
+	first, _ := NewConditional(tables)
+	second, _ := NewConditional(tables)
+	first.SetSeed(0)
+	second.SetSeed(0)
+
+	for i := 0; i < repeats; i++ {
+		k := i%2 + 1
+		a, _ := first.RandomGiven(k)
+		b, _ := second.RandomGiven(k)
+		if a != b {
+			t.Errorf("position %v got different result %v and %v", i, a, b)
+			t.FailNow()
+		}
+	}
+}
+
+func TestConditionalErrors(t *testing.T) {
+	if _, err := NewConditional([]int{1}); err != ErrNotMap {
+		t.Errorf("expected ErrNotMap, got %v", err)
+	}
+	if _, err := NewConditional(map[int]int{1: 1}); err != ErrType {
+		t.Errorf("expected ErrType, got %v", err)
+	}
+	if _, err := NewConditional(map[int]*Generator{1: nil}); err != ErrCondition {
+		t.Errorf("expected ErrCondition, got %v", err)
+	}
+}
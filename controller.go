@@ -0,0 +1,91 @@
+package discreteprobability
+
+import (
+	"sync"
+)
+
+// Controller draws values with weights nudged by the realized counts, so the short-run
+// frequencies converge to the weights of the generator faster than independent draws,
+// e.g. for a traffic split with a strict SLA. A value which has been drawn less than its
+// share gets a higher weight, and one drawn more a lower weight. The draws are no longer
+// independent. It's safe for concurrent use, as long as the Generator is not used elsewhere
+// at the same time.
+type Controller struct {
+	// Gain is the weight added per draw a value is behind its share, 0.1 by default.
+	// A higher gain converges faster, and 0 makes the draws independent.
+	Gain float64
+	// Decay is the factor of the counts after every draw, 1 by default. Below 1 the counts
+	// of the past draws fade, so the controller tracks the recent frequencies.
+	Decay float64
+
+	mu      sync.Mutex
+	g       *Generator
+	counts  []float64
+	total   float64
+	weights []float64
+}
+
+// Controller returns a new Controller with the weights of g as the targets.
+func (g *Generator) Controller() *Controller {
+	return &Controller{
+		Gain:    0.1,
+		Decay:   1,
+		g:       g,
+		counts:  make([]float64, g.size),
+		weights: make([]float64, g.size),
+	}
+}
+
+// Random returns a value drawn with the nudged weights.
+func (c *Controller) Random() interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sum := float64(0)
+	for i := range c.weights {
+		target := c.g.probability(i)
+		w := target + c.Gain*(target*c.total-c.counts[i])
+		if w < 0 || target == 0 {
+			w = 0
+		}
+		c.weights[i] = w
+		sum += w
+	}
+
+	// the nudges sum to zero before the negative weights are clipped, so sum is at least 1
+	i := pickWeight(c.weights, uniform(c.g.source)*sum)
+	c.g.observe(i, false)
+
+	for j := range c.counts {
+		c.counts[j] *= c.Decay
+	}
+	c.total = c.total*c.Decay + 1
+	c.counts[i]++
+	return c.g.values[i].Interface()
+}
+
+// pickWeight returns the index of the weight which covers f, walking the weights.
+func pickWeight(weights []float64, f float64) int {
+	last := 0
+	for i, w := range weights {
+		if w > 0 {
+			last = i
+			if f < w {
+				return i
+			}
+			f -= w
+		}
+	}
+	// rounding of the sum may leave f a little above the last weight
+	return last
+}
+
+// Reset forgets the counts.
+func (c *Controller) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range c.counts {
+		c.counts[i] = 0
+	}
+	c.total = 0
+}
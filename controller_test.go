@@ -0,0 +1,54 @@
+package discreteprobability
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestController(t *testing.T) {
+	g, _ := New([]string{"a", "b", "c"}, []float64{0.2, 0.3, 0.5})
+	g.SetSeed(time.Now().Unix())
+	targets := map[interface{}]float64{"a": 0.2, "b": 0.3, "c": 0.5}
+
+	// the largest deviation from the target counts over short runs
+	deviation := func(draw func() interface{}) float64 {
+		worst := float64(0)
+		counts := map[interface{}]float64{}
+		for n := 1; n <= 1000; n++ {
+			counts[draw()]++
+			if n%100 != 0 {
+				continue
+			}
+			for v, p := range targets {
+				worst = math.Max(worst, math.Abs(counts[v]-p*float64(n)))
+			}
+		}
+		return worst
+	}
+
+	c := g.Controller()
+	c.Gain = 1
+	controlled := deviation(c.Random)
+	if controlled > 3 {
+		t.Errorf("expected the counts within 3 of the targets, got %v", controlled)
+	}
+
+	c.Reset()
+	c.Gain = 0
+	if independent := deviation(c.Random); independent < controlled {
+		t.Errorf("expected independent draws to deviate more, got %v and %v", independent, controlled)
+	}
+}
+
+func TestControllerDecay(t *testing.T) {
+	g, _ := New([]string{"a", "b"}, []float64{0.5, 0.5})
+	c := g.Controller()
+	c.Decay = 0.5
+	for i := 0; i < 100; i++ {
+		c.Random()
+	}
+	if c.total > 2 {
+		t.Errorf("expected the counts to fade, got total %v", c.total)
+	}
+}
@@ -0,0 +1,127 @@
+package discreteprobability
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// valueOrder caches the order of the values of a generator for valueCDF. The weights
+// don't change after a generator is made, so the order is sorted once, and the clones
+// which copy the values in the same order share it.
+type valueOrder struct {
+	once       sync.Once
+	indexes    []int
+	cumulative []float64
+}
+
+// valueCDF returns the indexes of the values in ascending order of the values
+// together with the cumulative weights in that order. Values of kinds which
+// can't be compared keep the order of the generator. The slices are shared and
+// must not be modified.
+func (g *Generator) valueCDF() ([]int, []float64) {
+	if g.order == nil {
+		return g.sortValues()
+	}
+	g.order.once.Do(func() {
+		g.order.indexes, g.order.cumulative = g.sortValues()
+	})
+	return g.order.indexes, g.order.cumulative
+}
+
+// sortValues sorts the indexes of the values for valueCDF.
+func (g *Generator) sortValues() ([]int, []float64) {
+	order := make([]int, g.size)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return lessValue(g.values[order[i]], g.values[order[j]])
+	})
+
+	cumulative := make([]float64, g.size)
+	sum := float64(0)
+	for i, index := range order {
+		sum += g.probability(index)
+		cumulative[i] = sum
+	}
+	return order, cumulative
+}
+
+// lessValue reports whether a is less than b for numeric and string values.
+func lessValue(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	case reflect.String:
+		return a.String() < b.String()
+	}
+	return false
+}
+
+// SampleCorrelated returns a pair of values drawn from g1 and g2 whose rank correlation
+// approximates rho. With probability |rho| both draws share the same uniform
+// (or the mirrored one for a negative rho), otherwise they are independent.
+// Values are ranked by their natural order for numbers and strings.
+// It will return ErrCorrelation if rho is out of range [-1, 1]
+func SampleCorrelated(g1, g2 *Generator, rho float64) (interface{}, interface{}, error) {
+	if rho < -1 || rho > 1 {
+		return nil, nil, ErrCorrelation
+	}
+
+	order1, cdf1 := g1.valueCDF()
+	order2, cdf2 := g2.valueCDF()
+
+	u1 := uniform(g1.source)
+	var u2 float64
+	switch {
+	case uniform(g1.source) >= abs(rho):
+		u2 = uniform(g2.source)
+	case rho < 0:
+		u2 = 1 - u1
+	default:
+		u2 = u1
+	}
+
+	a := order1[searchCDF(cdf1, u1)]
+	b := order2[searchCDF(cdf2, u2)]
+	g1.observe(a, false)
+	g2.observe(b, false)
+	return g1.values[a].Interface(), g2.values[b].Interface(), nil
+}
+
+// searchCDF returns the first index whose cumulative weight covers f.
+func searchCDF(cumulative []float64, f float64) int {
+	i := sort.SearchFloat64s(cumulative, f)
+	if i == len(cumulative) {
+		return i - 1
+	}
+	return i
+}
+
+// uniformIndex returns the index of the value at the uniform u in [0, 1) of the CDF in
+// ascending order of the values, so the same u gives the same value regardless of the
+// order of the input. A value with zero weight is never returned.
+func (g *Generator) uniformIndex(u float64) int {
+	order, cdf := g.valueCDF()
+	i := sort.Search(len(cdf), func(i int) bool { return cdf[i] > u })
+	if i == len(cdf) {
+		// the rounding of the sum may leave u a little above the last cumulative weight
+		i--
+		for i > 0 && cdf[i] == cdf[i-1] {
+			i--
+		}
+	}
+	return order[i]
+}
+
+func abs(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
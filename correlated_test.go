@@ -0,0 +1,71 @@
+package discreteprobability
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSampleCorrelated(t *testing.T) {
+	g1 := generateInt(t, time.Now().Unix(), sliceLen)
+	g2 := generateInt(t, time.Now().Unix()+1, sliceLen)
+
+	for i := 0; i < repeats; i++ {
+		a, b, err := SampleCorrelated(g1, g2, 1)
+		if err != nil {
+			t.Errorf("SampleCorrelated error %v", err)
+			t.FailNow()
+		}
+		if a != b {
+			t.Errorf("expected identical ranks with rho 1, got %v and %v", a, b)
+			t.FailNow()
+		}
+	}
+
+	mirrored := 0
+	for i := 0; i < repeats; i++ {
+		a, b, _ := SampleCorrelated(g1, g2, -1)
+		if a.(int)+b.(int) == sliceLen-1 {
+			mirrored++
+		}
+	}
+	if mirrored < repeats*99/100 {
+		t.Errorf("expected mirrored ranks with rho -1, got %v of %v", mirrored, repeats)
+	}
+
+	same := 0
+	for i := 0; i < repeats; i++ {
+		a, b, _ := SampleCorrelated(g1, g2, 0.5)
+		if a == b {
+			same++
+		}
+	}
+	// half of the pairs share the uniform, the other half match by chance
+	p := float64(repeats) * (0.5 + 0.5/sliceLen)
+	if d := p * 3 / 100; float64(same) > p+d || float64(same) < p-d {
+		t.Errorf("incorrect correlation, expected %f equal pairs, got %d", p, same)
+	}
+}
+
+func TestSampleCorrelatedRange(t *testing.T) {
+	g := generateInt(t, 1, sliceLen)
+	if _, _, err := SampleCorrelated(g, g, 1.5); err != ErrCorrelation {
+		t.Errorf("expected ErrCorrelation, got %v", err)
+	}
+}
+
+func TestValueCDFCached(t *testing.T) {
+	g, _ := New([]int{3, 1, 2}, []float64{0.5, 0.2, 0.3})
+	order, cdf := g.valueCDF()
+	if again, _ := g.valueCDF(); &again[0] != &order[0] {
+		t.Errorf("expected the order to be sorted once")
+	}
+	for i, v := range []int{1, 2, 3} {
+		if int(g.values[order[i]].Int()) != v {
+			t.Errorf("unexpected order %v", order)
+			t.FailNow()
+		}
+	}
+	if cdf[0] < 0.2-1e-9 || cdf[0] > 0.2+1e-9 || cdf[2] != 1 {
+		t.Errorf("unexpected cdf %v", cdf)
+	}
+}
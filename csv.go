@@ -0,0 +1,113 @@
+package discreteprobability
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CSVOptions is the options of NewFromCSV
+type CSVOptions struct {
+	// Comma is the field delimiter, ',' if zero. Use '\t' for TSV.
+	Comma rune
+	// ValueHeader and WeightHeader select the columns by the header names.
+	// If empty, the columns are selected by ValueColumn and WeightColumn.
+	ValueHeader  string
+	WeightHeader string
+	// ValueColumn and WeightColumn are the indexes of the columns. If both are zero,
+	// the first column holds the values and the second holds the weights.
+	ValueColumn  int
+	WeightColumn int
+	// Normalize scales the weights to sum to 1, e.g. for raw counts.
+	Normalize bool
+	// IntValues parses the values as ints instead of strings.
+	IntValues bool
+}
+
+// NewFromCSV returns a new Generator with the values and weights read from r.
+// The first record is treated as a header if its weight can't be parsed as a number.
+// It will return ErrColumn if a column is not found, ErrSpec if a value or weight is
+// not valid, or any error of New.
+func NewFromCSV(r io.Reader, opts CSVOptions) (*Generator, error) {
+	reader := csv.NewReader(r)
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+	}
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, ErrSpec
+	}
+
+	valueIndex, weightIndex := opts.ValueColumn, opts.WeightColumn
+	if valueIndex == 0 && weightIndex == 0 {
+		weightIndex = 1
+	}
+	if valueIndex < 0 || weightIndex < 0 {
+		return nil, ErrColumn
+	}
+
+	header := opts.ValueHeader != "" || opts.WeightHeader != ""
+	if !header && weightIndex < len(records[0]) {
+		_, err := strconv.ParseFloat(strings.TrimSpace(records[0][weightIndex]), 64)
+		header = err != nil
+	}
+	if header {
+		for i, name := range records[0] {
+			name = strings.TrimSpace(name)
+			if opts.ValueHeader != "" && name == opts.ValueHeader {
+				valueIndex = i
+			}
+			if opts.WeightHeader != "" && name == opts.WeightHeader {
+				weightIndex = i
+			}
+		}
+		if valueIndex >= len(records[0]) || weightIndex >= len(records[0]) {
+			return nil, ErrColumn
+		}
+		if opts.ValueHeader != "" && strings.TrimSpace(records[0][valueIndex]) != opts.ValueHeader ||
+			opts.WeightHeader != "" && strings.TrimSpace(records[0][weightIndex]) != opts.WeightHeader {
+			return nil, ErrColumn
+		}
+		records = records[1:]
+	}
+
+	var texts []string
+	var ints []int
+	weights := make([]float64, len(records))
+	for i, record := range records {
+		if valueIndex >= len(record) || weightIndex >= len(record) {
+			return nil, ErrColumn
+		}
+
+		weights[i], err = strconv.ParseFloat(strings.TrimSpace(record[weightIndex]), 64)
+		if err != nil {
+			return nil, ErrSpec
+		}
+
+		value := strings.TrimSpace(record[valueIndex])
+		if !opts.IntValues {
+			texts = append(texts, value)
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, ErrSpec
+		}
+		ints = append(ints, n)
+	}
+
+	if opts.Normalize {
+		if weights, err = normalize(weights); err != nil {
+			return nil, err
+		}
+	}
+	if opts.IntValues {
+		return New(ints, weights)
+	}
+	return New(texts, weights)
+}
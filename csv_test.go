@@ -0,0 +1,69 @@
+package discreteprobability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewFromCSV(t *testing.T) {
+	g, err := NewFromCSV(strings.NewReader("item,weight\nsword,0.1\nshield,0.3\npotion,0.6\n"), CSVOptions{})
+	if err != nil {
+		t.Errorf("NewFromCSV error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(g, "sword", 0.1) || !weightEqual(g, "shield", 0.3) || !weightEqual(g, "potion", 0.6) {
+		t.Errorf("unexpected generator %v", g)
+	}
+}
+
+func TestNewFromCSVHeaderNames(t *testing.T) {
+	tsv := "count\tid\tname\n30\t1\tsword\n10\t2\tshield\n"
+	g, err := NewFromCSV(strings.NewReader(tsv), CSVOptions{
+		Comma:        '\t',
+		ValueHeader:  "id",
+		WeightHeader: "count",
+		Normalize:    true,
+		IntValues:    true,
+	})
+	if err != nil {
+		t.Errorf("NewFromCSV error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(g, 1, 0.75) || !weightEqual(g, 2, 0.25) {
+		t.Errorf("unexpected generator %v", g)
+	}
+
+	_, err = NewFromCSV(strings.NewReader(tsv), CSVOptions{Comma: '\t', ValueHeader: "item", WeightHeader: "count"})
+	if err != ErrColumn {
+		t.Errorf("expected ErrColumn, got %v", err)
+	}
+}
+
+func TestNewFromCSVNoHeader(t *testing.T) {
+	g, err := NewFromCSV(strings.NewReader("0.5,a\n0.5,b\n"), CSVOptions{ValueColumn: 1, WeightColumn: 0})
+	if err != nil {
+		t.Errorf("NewFromCSV error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(g, "a", 0.5) || !weightEqual(g, "b", 0.5) {
+		t.Errorf("unexpected generator %v", g)
+	}
+
+	if _, err := NewFromCSV(strings.NewReader("a,0.5\nb,x\n"), CSVOptions{}); err != ErrSpec {
+		t.Errorf("expected ErrSpec, got %v", err)
+	}
+}
+
+func TestNewFromCSVColumnRange(t *testing.T) {
+	tests := []CSVOptions{
+		{ValueHeader: "name", ValueColumn: 5},
+		{WeightHeader: "count", WeightColumn: 5},
+		{ValueColumn: -1, WeightColumn: 1},
+	}
+	for _, opts := range tests {
+		_, err := NewFromCSV(strings.NewReader("item,weight\nsword,1\n"), opts)
+		if err != ErrColumn {
+			t.Errorf("expected ErrColumn for %+v, got %v", opts, err)
+		}
+	}
+}
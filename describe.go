@@ -0,0 +1,54 @@
+package discreteprobability
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// String returns the values with their probabilities in a single line,
+// e.g. Generator{c: 0.5, b: 0.25, a: 0.25}
+func (g *Generator) String() string {
+	var b strings.Builder
+	b.WriteString("Generator{")
+	for i := g.size - 1; i >= 0; i-- {
+		if i != g.size-1 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%v: %g", g.values[i].Interface(), g.probability(i))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// Describe returns a table of the values and their probabilities,
+// from the most probable value to the least.
+func (g *Generator) Describe() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VALUE\tPROBABILITY")
+	for i := g.size - 1; i >= 0; i-- {
+		p := g.probability(i)
+		fmt.Fprintf(w, "%v\t%.4f%%\n", g.values[i].Interface(), p*100)
+	}
+	w.Flush()
+	return b.String()
+}
+
+// Distribution returns the distinct values and their probabilities in the order of the
+// generator, which is sorted by weight. Duplicated values are merged into one.
+func (g *Generator) Distribution() ([]interface{}, []float64) {
+	set := newValueSet(g.size)
+	weights := make([]float64, 0, g.size)
+	for i, value := range g.values {
+		v := value.Interface()
+		j := set.index(v)
+		if j < 0 {
+			set.add(v)
+			weights = append(weights, g.probability(i))
+			continue
+		}
+		weights[j] += g.probability(i)
+	}
+	return set.values, weights
+}
@@ -0,0 +1,46 @@
+package discreteprobability
+
+import "testing"
+
+func TestString(t *testing.T) {
+	g, _ := New([]string{"a", "b", "c"}, []float64{0.2, 0.5, 0.3})
+	expected := "Generator{b: 0.5, c: 0.3, a: 0.2}"
+	if s := g.String(); s != expected {
+		t.Errorf("expected %v, got %v", expected, s)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	g, _ := New([]int{1, 20, 300}, []float64{0.2, 0.5, 0.3})
+	expected := "VALUE  PROBABILITY\n" +
+		"20     50.0000%\n" +
+		"300    30.0000%\n" +
+		"1      20.0000%\n"
+	if s := g.Describe(); s != expected {
+		t.Errorf("expected\n%v\ngot\n%v", expected, s)
+	}
+}
+
+func TestDistributionValues(t *testing.T) {
+	g, _ := New([]string{"a", "b", "a"}, []float64{0.25, 0.5, 0.25})
+	values, weights := g.Distribution()
+	if len(values) != 2 || !(values[0] == "a" && weights[0] == 0.5 || values[1] == "a" && weights[1] == 0.5) {
+		t.Errorf("expected the duplicated values to be merged, got %v %v", values, weights)
+	}
+
+	// a large generator is merged in linear time
+	const n = 100000
+	large := make([]int, n)
+	w := make([]float64, n)
+	for i := range large {
+		large[i] = i % (n / 2)
+		w[i] = 1.0 / n
+	}
+	g, _ = New(large, w)
+	if values, _ := g.Distribution(); len(values) != n/2 {
+		t.Errorf("expected %v distinct values, got %v", n/2, len(values))
+	}
+	if !g.Equal(g.Clone()) {
+		t.Errorf("expected the clone to be equal")
+	}
+}
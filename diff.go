@@ -0,0 +1,129 @@
+package discreteprobability
+
+import "reflect"
+
+// weightEpsilon is the tolerance when comparing two probabilities.
+const weightEpsilon = 1e-9
+
+// WeightDiff is the difference of the probability of a value between two generators.
+// A value which is missing in one of the generators has the probability 0 there.
+type WeightDiff struct {
+	Value interface{}
+	Old   float64
+	New   float64
+}
+
+// valueSet finds the index of a value in O(1) for the values whose equality is the same as
+// reflect.DeepEqual, such as numbers, strings and structs of them, and by reflect.DeepEqual
+// over the other values, such as pointers and slices.
+type valueSet struct {
+	values []interface{}
+	keys   map[interface{}]int
+	others []int
+	plain  map[reflect.Type]bool
+}
+
+func newValueSet(n int) *valueSet {
+	return &valueSet{
+		values: make([]interface{}, 0, n),
+		keys:   make(map[interface{}]int, n),
+		plain:  map[reflect.Type]bool{},
+	}
+}
+
+// index returns the index of v, or -1 if not found.
+func (s *valueSet) index(v interface{}) int {
+	if s.isPlain(v) {
+		if i, ok := s.keys[v]; ok {
+			return i
+		}
+		return -1
+	}
+	for _, i := range s.others {
+		if reflect.DeepEqual(s.values[i], v) {
+			return i
+		}
+	}
+	return -1
+}
+
+// add appends v, which is not in the set yet, and returns its index.
+func (s *valueSet) add(v interface{}) int {
+	i := len(s.values)
+	s.values = append(s.values, v)
+	if s.isPlain(v) {
+		s.keys[v] = i
+	} else {
+		s.others = append(s.others, i)
+	}
+	return i
+}
+
+func (s *valueSet) isPlain(v interface{}) bool {
+	if v == nil {
+		return false
+	}
+	t := reflect.TypeOf(v)
+	p, ok := s.plain[t]
+	if !ok {
+		p = plainType(t)
+		s.plain[t] = p
+	}
+	return p
+}
+
+// plainType reports whether == on the values of t is the same as reflect.DeepEqual.
+func plainType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128, reflect.String:
+		return true
+	case reflect.Array:
+		return plainType(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !plainType(t.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// Equal reports whether g and other have the same values with the same probabilities.
+func (g *Generator) Equal(other *Generator) bool {
+	return len(g.Diff(other)) == 0
+}
+
+// Diff returns the values whose probabilities are different in other, in the order
+// of g followed by the values only in other.
+func (g *Generator) Diff(other *Generator) []WeightDiff {
+	oldValues, oldWeights := g.Distribution()
+	newValues, newWeights := other.Distribution()
+
+	set := newValueSet(len(newValues))
+	for _, v := range newValues {
+		set.add(v)
+	}
+
+	var diffs []WeightDiff
+	seen := make([]bool, len(newValues))
+	for i, v := range oldValues {
+		p := float64(0)
+		if j := set.index(v); j >= 0 {
+			p = newWeights[j]
+			seen[j] = true
+		}
+		if abs(oldWeights[i]-p) > weightEpsilon {
+			diffs = append(diffs, WeightDiff{Value: v, Old: oldWeights[i], New: p})
+		}
+	}
+	for j, v := range newValues {
+		if !seen[j] && newWeights[j] > weightEpsilon {
+			diffs = append(diffs, WeightDiff{Value: v, New: newWeights[j]})
+		}
+	}
+	return diffs
+}
@@ -0,0 +1,42 @@
+package discreteprobability
+
+import "testing"
+
+func TestEqual(t *testing.T) {
+	a, _ := New([]string{"a", "b", "c"}, []float64{0.25, 0.25, 0.5})
+	b, _ := New([]string{"c", "b", "a"}, []float64{0.5, 0.25, 0.25})
+	if !a.Equal(b) {
+		t.Errorf("expected generators to be equal")
+	}
+
+	c, _ := New([]string{"a", "b", "c"}, []float64{0.2, 0.3, 0.5})
+	if a.Equal(c) {
+		t.Errorf("expected generators to be different")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a, _ := New([]string{"a", "b", "c"}, []float64{0.25, 0.25, 0.5})
+	b, _ := New([]string{"a", "c", "d"}, []float64{0.25, 0.5, 0.25})
+
+	diffs := a.Diff(b)
+	if len(diffs) != 2 {
+		t.Errorf("expected 2 diffs, got %v", diffs)
+		t.FailNow()
+	}
+	if diffs[0].Value != "b" || diffs[0].Old != 0.25 || diffs[0].New != 0 {
+		t.Errorf("incorrect diff %+v", diffs[0])
+	}
+	if diffs[1].Value != "d" || diffs[1].Old != 0 || diffs[1].New != 0.25 {
+		t.Errorf("incorrect diff %+v", diffs[1])
+	}
+}
+
+func TestDiffDeepEqual(t *testing.T) {
+	type point struct{ X, Y int }
+	a, _ := New([]interface{}{point{1, 2}, &point{3, 4}, []int{5}}, []float64{0.5, 0.25, 0.25})
+	b, _ := New([]interface{}{[]int{5}, &point{3, 4}, point{1, 2}}, []float64{0.25, 0.25, 0.5})
+	if diffs := a.Diff(b); len(diffs) != 0 {
+		t.Errorf("expected the values to be equal by reflect.DeepEqual, got %v", diffs)
+	}
+}
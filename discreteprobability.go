@@ -16,9 +16,11 @@ package discreteprobability
 
 import (
 	"errors"
+	"math"
 	"math/rand"
 	"reflect"
 	"sort"
+	"sync"
 	"time"
 )
 
@@ -29,7 +31,47 @@ var ErrNotSlice			= errors.New("value is not a slice")
 // ErrLength is returned when the length of values and weights are different
 var ErrLength			= errors.New("length of values and weights not match")
 // ErrWeightSum is returned when the sum of weights is not 1
-var ErrWeightSum		= errors.New("")
+var ErrWeightSum		= errors.New("sum of weights not equal to 1")
+// ErrNegativeWeight is returned when any of the weights is negative
+var ErrNegativeWeight	= errors.New("weight is negative")
+// ErrGroup is returned when the groups and the group weights have different keys
+var ErrGroup			= errors.New("groups and group weights not match")
+// ErrNotMap is returned when the type of value is not a map
+var ErrNotMap			= errors.New("value is not a map")
+// ErrCondition is returned when there is no generator for the given condition
+var ErrCondition		= errors.New("no generator for the condition")
+// ErrCorrelation is returned when the correlation is out of range [-1, 1]
+var ErrCorrelation		= errors.New("correlation out of range")
+// ErrSpec is returned when a text spec of values and weights is malformed
+var ErrSpec				= errors.New("invalid spec")
+// ErrColumn is returned when a column is not found in the rows
+var ErrColumn			= errors.New("column not found")
+// ErrQuota is returned when the quotas can't be satisfied
+var ErrQuota			= errors.New("quotas can not be satisfied")
+// ErrNotEnough is returned when there are not enough values with positive weights
+var ErrNotEnough		= errors.New("not enough values with positive weights")
+// ErrTemperature is returned when the softmax temperature is not positive
+var ErrTemperature		= errors.New("temperature is not positive")
+// ErrProbability is returned when a probability is out of range [0, 1]
+var ErrProbability		= errors.New("probability out of range")
+// ErrValue is returned when the value is not one of the values of the generator
+var ErrValue			= errors.New("value not found")
+// ErrEmptyInput is returned when there are no values
+var ErrEmptyInput		= errors.New("no values")
+// ErrOverflow is returned when an integer value doesn't fit in an int
+var ErrOverflow			= errors.New("value overflows int")
+// ErrRepeat is returned when the max repeat is not positive
+var ErrRepeat			= errors.New("max repeat is not positive")
+// ErrRate is returned when a rate of events is not positive
+var ErrRate				= errors.New("rate is not positive")
+// ErrProof is returned when a committed draw doesn't match its commitment or proof
+var ErrProof			= errors.New("draw does not match the proof")
+// ErrShortOutput is returned when random bytes are fewer than the 8 needed for a draw
+var ErrShortOutput		= errors.New("random output shorter than 8 bytes")
+// ErrNull is returned when a value scanned from the rows is NULL
+var ErrNull				= errors.New("value is NULL")
+// ErrNilWeight is returned when any of the exact weights is nil
+var ErrNilWeight		= errors.New("weight is nil")
 
 var seed = time.Now().UnixNano()
 
@@ -40,6 +82,15 @@ type Generator struct {
 	weights 		[]float64
 	size 			int
 	source			rand.Source
+	tickSeed		int64
+	exact			*exactTable
+	buffers			*sync.Pool
+	layout			eytzinger
+	runs			runs
+	order			*valueOrder
+	onDraw			func(index int, v interface{}, p float64)
+	tracer			Tracer
+	logger			*drawLogger
 }
 
 func (g *Generator) Len() int { return len(g.values) }
@@ -50,28 +101,53 @@ func (g *Generator) Swap(i, j int) {
 func (g *Generator) Less(i, j int) bool { return g.weights[i] < g.weights[j] }
 
 
-// New returns a new Generator. It will return error if values and weights have different length
-// or the sum of weights not equal to 1
+// New returns a new Generator. It will return error if there are no values, values and weights
+// have different length or the sum of weights not equal to 1
 func New(v interface{}, w []float64) (*Generator, error) {
-	t := reflect.TypeOf(v).Kind()
-	if t != reflect.Slice {
-		return nil, ErrNotSlice
+	values, err := sliceValues(v)
+	if err != nil {
+		return nil, err
 	}
 
-	val := reflect.ValueOf(v)
-	values := make([]reflect.Value, val.Len())
-	for i := 0; i < val.Len(); i++ {
-		values[i] = val.Index(i)
+	return newGenerator(values, w)
+}
+
+// NewNormalized returns a new Generator with the weights scaled to sum to 1, e.g. for raw counts.
+// It will return error if values and weights have different length, any weight is negative
+// or the sum of weights is not positive
+func NewNormalized(v interface{}, w []float64) (*Generator, error) {
+	for _, weight := range w {
+		if weight < 0 {
+			return nil, ErrNegativeWeight
+		}
 	}
+	weights, err := normalize(w)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(v, weights)
+}
+
+// weightTolerance is the largest difference from 1 of the sum of weights.
+const weightTolerance = 1e-4
 
+// newGenerator returns a new Generator over the reflect values. The weights are
+// sorted and accumulated in place.
+func newGenerator(values []reflect.Value, w []float64) (*Generator, error) {
 	if len(values) != len(w) {
 		return nil, ErrLength
 	}
+	if len(values) == 0 {
+		return nil, ErrEmptyInput
+	}
 	s := &Generator{
 		values: 		values,
 		weights: 		w,
 		size:			len(values),
 		source:			rand.NewSource(seed),
+		tickSeed:		seed,
+		order:			&valueOrder{},
 	}
 
 	sort.Sort(s)
@@ -81,26 +157,120 @@ func New(v interface{}, w []float64) (*Generator, error) {
 		sum += weight
 		s.weights[i] = sum
 	}
-	if sum - 1 > 1e-4 {
+	// a NaN weight makes the sum NaN, which fails the check too
+	if !(math.Abs(sum - 1) <= weightTolerance) {
 		return nil, ErrWeightSum
 	}
+	// the last cumulative weight is exactly 1, so every random number in [0, 1) is covered
+	for i := range s.weights {
+		s.weights[i] /= sum
+	}
+	s.runs = newRuns(s.weights)
+	if s.runs == nil && s.size >= eytzingerMinSize && s.size <= eytzingerMaxSize {
+		s.layout = newEytzinger(s.weights)
+	}
 
 	return s, nil
 }
 
+// sliceValues returns the elements of the slice v as reflect values.
+func sliceValues(v interface{}) ([]reflect.Value, error) {
+	t := reflect.TypeOf(v).Kind()
+	if t != reflect.Slice {
+		return nil, ErrNotSlice
+	}
+
+	val := reflect.ValueOf(v)
+	values := make([]reflect.Value, val.Len())
+	for i := 0; i < val.Len(); i++ {
+		values[i] = val.Index(i)
+	}
+	return values, nil
+}
 
 // SetSeed is to set a custom random seed other than the time stamp.
 func (g *Generator) SetSeed(s int64) {
 	g.source = rand.NewSource(s)
+	g.tickSeed = s
 }
 
-func (g *Generator) random() reflect.Value {
-	f := float64(g.source.Int63()) / (1 << 63)
-	i := sort.Search(g.size, func(i int) bool {
+// SetSource is to draw with a custom random source, e.g. a BeaconSource. The source is used
+// as is, so it should not be shared with another generator unless it's safe for concurrent use.
+func (g *Generator) SetSource(s rand.Source) {
+	g.source = s
+}
+
+func (g *Generator) index() int {
+	i := g.pick(g.source)
+	g.observe(i, false)
+	return i
+}
+
+// pick returns the index of a value drawn with the randomness of source.
+func (g *Generator) pick(source rand.Source) int {
+	// a single value is always drawn, without the random source
+	if g.size == 1 {
+		return 0
+	}
+	if g.exact != nil {
+		return g.exact.index(source)
+	}
+
+	return g.search(uniform(source))
+}
+
+// linearSize is the largest number of values searched with a linear scan.
+const linearSize = 16
+
+// search returns the index of the value whose cumulative weight covers f.
+func (g *Generator) search(f float64) int {
+	if g.runs != nil {
+		return g.runs.search(f, g.size)
+	}
+	if g.size <= linearSize {
+		return linearSearch(g.weights, f)
+	}
+	if g.layout != nil {
+		return g.layout.search(f, g.size)
+	}
+	return sort.Search(g.size, func(i int) bool {
 		return g.weights[i] >= f
 	})
+}
 
-	return g.values[i]
+// linearSearch counts the cumulative weights below f, which is the index of the first
+// weight covering f. The loop has no early exit and the comparison compiles to a
+// conditional set, so there is no branch to mispredict.
+func linearSearch(weights []float64, f float64) int {
+	i := 0
+	for _, w := range weights {
+		below := 0
+		if w < f {
+			below = 1
+		}
+		i += below
+	}
+	return i
+}
+
+// probability returns the weight of the value at index i.
+func (g *Generator) probability(i int) float64 {
+	if g.exact != nil {
+		return g.exact.probability(i)
+	}
+	if i == 0 {
+		return g.weights[0]
+	}
+	return g.weights[i] - g.weights[i-1]
+}
+
+// uniform returns a random float64 in [0, 1) from source.
+func uniform(source rand.Source) float64 {
+	return float64(source.Int63()) / (1 << 63)
+}
+
+func (g *Generator) random() reflect.Value {
+	return g.values[g.index()]
 }
 
 // RandomInt returns the int value from the value set with corresponding weights without type assertion.
@@ -121,13 +291,58 @@ func (g *Generator) RandomString() string {
 	return g.random().String()
 }
 
+// RandomInterface returns the value from the value set with corresponding weights as an interface{}.
+// It works with values of any type, such as a slice of custom structs.
+func (g *Generator) RandomInterface() interface{} {
+	return g.random().Interface()
+}
+
+// RandomInto stores the value from the value set with corresponding weights into dst,
+// which should be a pointer to a variable of the type of the values, e.g. *MyStruct for []MyStruct.
+// It will return ErrType if dst is not a non-nil pointer the value can be assigned to.
+// No value is drawn in that case.
+func (g *Generator) RandomInto(dst interface{}) error {
+	ptr := reflect.ValueOf(dst)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() {
+		return ErrType
+	}
+	elem := ptr.Elem()
+	if g.size > 0 && !g.values[0].Type().AssignableTo(elem.Type()) {
+		return ErrType
+	}
+
+	elem.Set(g.random())
+	return nil
+}
+
+// RandomWeighted returns the value from the value set with corresponding weights
+// together with the probability of drawing it.
+func (g *Generator) RandomWeighted() (interface{}, float64) {
+	i := g.index()
+	return g.values[i].Interface(), g.probability(i)
+}
+
+// RandomInt64 returns the int64 value from the value set with corresponding weights without type assertion.
+// It works with any signed integer values, including the int64 values which don't fit in an int
+// on 32-bit platforms. Will panic if input value is not a slice of signed integers
+func (g *Generator) RandomInt64() int64 {
+	return g.random().Int()
+}
+
 // RandomIntSafe returns the int value from the value set with corresponding weights.
+// Any signed integer values are accepted. It will return ErrOverflow if the value doesn't fit in an int,
+// e.g. a large int64 value on 32-bit platforms.
 func (g *Generator) RandomIntSafe() (int, error) {
-	r, ok := g.random().Interface().(int)
-	if !ok {
-		return r, ErrType
+	v := g.random()
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		r := v.Int()
+		if !fitsInt(r) {
+			return 0, ErrOverflow
+		}
+		return int(r), nil
 	}
-	return r, nil
+	return 0, ErrType
 }
 
 
@@ -2,6 +2,9 @@ package discreteprobability
 
 import (
 	"fmt"
+	"math"
+	"math/rand"
+	"sort"
 	"strconv"
 	"testing"
 	"time"
@@ -77,6 +80,143 @@ func TestRandomStringSafe(t *testing.T) {
 	}
 }
 
+func TestRandomWeighted(t *testing.T) {
+	g, _ := New([]string{"a", "b"}, []float64{0.25, 0.75})
+	for i := 0; i < sliceLen; i++ {
+		v, p := g.RandomWeighted()
+		if !weightEqual(g, v, p) {
+			t.Errorf("RandomWeighted got value %v with probability %v", v, p)
+			t.FailNow()
+		}
+	}
+}
+
+func TestWeightSum(t *testing.T) {
+	for _, w := range [][]float64{{0.2, 0.2}, {0.6, 0.6}, {0.5, 0.49}, {math.NaN(), 1}} {
+		if _, err := New([]int{1, 2}, w); err != ErrWeightSum {
+			t.Errorf("weights %v expected ErrWeightSum, got %v", w, err)
+		}
+	}
+
+	// within the tolerance the weights are scaled, so the last value doesn't take the rest
+	g, err := New([]int{1, 2}, []float64{0.49999, 0.49999})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+	if g.weights[1] != 1 || !weightEqual(g, 1, 0.5) {
+		t.Errorf("expected the weights scaled to 1, got %v", g.weights)
+	}
+}
+
+func TestNewNormalized(t *testing.T) {
+	g, err := NewNormalized([]string{"a", "b"}, []float64{1, 3})
+	if err != nil {
+		t.Errorf("NewNormalized error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(g, "a", 0.25) || !weightEqual(g, "b", 0.75) {
+		t.Errorf("unexpected generator %v", g)
+	}
+
+	if _, err := NewNormalized([]string{"a", "b"}, []float64{0, 0}); err != ErrWeightSum {
+		t.Errorf("expected ErrWeightSum, got %v", err)
+	}
+	if _, err := NewNormalized([]string{"a", "b"}, []float64{-1, 3}); err != ErrNegativeWeight {
+		t.Errorf("expected ErrNegativeWeight, got %v", err)
+	}
+	if _, err := NewNormalized([]string{"a", "b"}, []float64{1}); err != ErrLength {
+		t.Errorf("expected ErrLength, got %v", err)
+	}
+}
+
+func TestEmptyInput(t *testing.T) {
+	if _, err := New([]int{}, []float64{}); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := New([]int{}, []float64{1}); err != ErrLength {
+		t.Errorf("expected ErrLength, got %v", err)
+	}
+	if _, err := NewExact([]int{}, nil); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := NewEnum(0, nil); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+}
+
+func TestSingleValue(t *testing.T) {
+	g, err := New([]string{"a"}, []float64{1})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+	g.SetSeed(1)
+	for i := 0; i < 100; i++ {
+		if v := g.RandomString(); v != "a" {
+			t.Errorf("unexpected value %v", v)
+			t.FailNow()
+		}
+	}
+	if g.source.Int63() != rand.NewSource(1).Int63() {
+		t.Errorf("expected the random source to be untouched")
+	}
+	if a := g.Algorithm(); a != "single" {
+		t.Errorf("expected single algorithm, got %v", a)
+	}
+}
+
+func TestRandomInto(t *testing.T) {
+	type point struct{ X, Y int }
+	g, _ := New([]point{{1, 2}, {3, 4}}, []float64{0.25, 0.75})
+	var p point
+	if err := g.RandomInto(&p); err != nil {
+		t.Errorf("RandomInto error %v", err)
+		t.FailNow()
+	}
+	if p != (point{1, 2}) && p != (point{3, 4}) {
+		t.Errorf("unexpected value %v", p)
+	}
+
+	var i int
+	if err := g.RandomInto(&i); err != ErrType {
+		t.Errorf("expected ErrType, got %v", err)
+	}
+	if err := g.RandomInto(p); err != ErrType {
+		t.Errorf("expected ErrType, got %v", err)
+	}
+	if err := g.RandomInto((*point)(nil)); err != ErrType {
+		t.Errorf("expected ErrType, got %v", err)
+	}
+}
+
+func TestLinearSearch(t *testing.T) {
+	g := generateInt(t, time.Now().Unix(), linearSize)
+	for i := 0; i < repeats; i++ {
+		f := uniform(g.source)
+		expected := sort.Search(g.size, func(i int) bool {
+			return g.weights[i] >= f
+		})
+		if got := linearSearch(g.weights, f); got != expected {
+			t.Errorf("linear search of %v expected %v, got %v", f, expected, got)
+			t.FailNow()
+		}
+	}
+}
+
+// weightEqual reports whether the value v has the probability p in g.
+func weightEqual(g *Generator, v interface{}, p float64) bool {
+	last := float64(0)
+	for i, value := range g.values {
+		if value.Interface() == v {
+			w := g.weights[i] - last
+			return w > p-1e-9 && w < p+1e-9
+		}
+		last = g.weights[i]
+	}
+	return false
+}
+
 func resultInt(t *testing.T, seed int64, size int) []int {
 	g := generateInt(t, seed, sliceLen)
 	v := make([]int, 0, size)
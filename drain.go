@@ -0,0 +1,49 @@
+package discreteprobability
+
+import (
+	"reflect"
+)
+
+// Drain draws k values without replacement and returns them with a new Generator over
+// the remaining values, renormalized. Fewer than k values are returned if there are
+// not enough values with positive weights, and the new Generator is nil if none of the
+// remaining values has a positive weight. The random stream of the new Generator is seeded from g.
+// If k is not positive, no values are drawn and the samples are nil, as for SampleN.
+func (g *Generator) Drain(k int) ([]interface{}, *Generator) {
+	buf := g.getPicked(g.size)
+	defer g.putPicked(buf)
+	picked := *buf
+	all := func(int) bool { return true }
+	var samples []interface{}
+	if k > 0 {
+		samples = make([]interface{}, 0, k)
+	}
+	for len(samples) < k {
+		i := g.drawWithout(picked, all)
+		if i < 0 {
+			break
+		}
+		picked[i] = true
+		samples = append(samples, g.values[i].Interface())
+	}
+
+	values := make([]reflect.Value, 0, g.size-len(samples))
+	weights := make([]float64, 0, g.size-len(samples))
+	for i, value := range g.values {
+		if !picked[i] {
+			values = append(values, value)
+			weights = append(weights, g.probability(i))
+		}
+	}
+	weights, err := normalize(weights)
+	if err != nil {
+		return samples, nil
+	}
+
+	rest, err := newGenerator(values, weights)
+	if err != nil {
+		return samples, nil
+	}
+	rest.SetSeed(g.source.Int63())
+	return samples, rest
+}
@@ -0,0 +1,58 @@
+package discreteprobability
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDrain(t *testing.T) {
+	g := generateInt(t, time.Now().Unix(), sliceLen)
+	samples, rest := g.Drain(3)
+	if len(samples) != 3 || rest == nil || rest.size != sliceLen-3 {
+		t.Errorf("unexpected drain %v %v", samples, rest)
+		t.FailNow()
+	}
+
+	for _, s := range samples {
+		if contains(rest, s) {
+			t.Errorf("drained value %v is still in the generator", s)
+		}
+	}
+	for i := range rest.values {
+		if p := rest.probability(i); p < 1.0/7-1e-9 || p > 1.0/7+1e-9 {
+			t.Errorf("expected renormalized weights, got %v", rest)
+			t.FailNow()
+		}
+	}
+
+	samples, rest = rest.Drain(10)
+	if len(samples) != sliceLen-3 || rest != nil {
+		t.Errorf("expected to drain everything, got %v %v", samples, rest)
+	}
+}
+
+func TestDrainNegative(t *testing.T) {
+	g := generateInt(t, 42, sliceLen)
+	samples, rest := g.Drain(-1)
+	if samples != nil || rest == nil || rest.size != sliceLen {
+		t.Errorf("expected no samples and all the values, got %v %v", samples, rest)
+	}
+}
+
+func TestDrainSeed(t *testing.T) {
+	g := generateInt(t, 42, sliceLen)
+	replay := generateInt(t, 42, sliceLen)
+	_, rest := g.Drain(3)
+	_, replayRest := replay.Drain(3)
+	// the rest takes its tick seed from the generator, not the package seed
+	if rest.tickSeed == seed {
+		t.Errorf("expected the rest to be seeded by the generator")
+		t.FailNow()
+	}
+	for tick := uint64(0); tick < 100; tick++ {
+		if a, b := rest.RandomAtTick(tick), replayRest.RandomAtTick(tick); a != b {
+			t.Errorf("tick %d drew %v and %v with the same seed", tick, a, b)
+			t.FailNow()
+		}
+	}
+}
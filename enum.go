@@ -0,0 +1,88 @@
+package discreteprobability
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Enum generates weighted indexes 0 to n-1 without any values, for the codes of
+// a small enum, e.g. the message types of a protocol simulator or the bases of a genome.
+// The weights keep their order, so an index is the position of its weight.
+type Enum struct {
+	weights []float64
+	source  rand.Source
+}
+
+// NewEnum returns a new Enum over the indexes 0 to n-1. It will return error if n is 0, n and
+// the length of weights are different, any weight is negative or the sum of weights not equal to 1
+func NewEnum(n int, weights []float64) (*Enum, error) {
+	if n != len(weights) {
+		return nil, ErrLength
+	}
+	if n == 0 {
+		return nil, ErrEmptyInput
+	}
+
+	e := &Enum{
+		weights: make([]float64, n),
+		source:  rand.NewSource(seed),
+	}
+	sum := float64(0)
+	for i, weight := range weights {
+		if weight < 0 {
+			return nil, ErrNegativeWeight
+		}
+		sum += weight
+		e.weights[i] = sum
+	}
+	if !(math.Abs(sum-1) <= weightTolerance) {
+		return nil, ErrWeightSum
+	}
+	// the last cumulative weight is exactly 1, so the last index doesn't take the rest of the mass
+	for i := range e.weights {
+		e.weights[i] /= sum
+	}
+
+	return e, nil
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (e *Enum) SetSeed(s int64) {
+	e.source = rand.NewSource(s)
+}
+
+// RandomIndex returns an index from 0 to n-1 with the corresponding weight.
+func (e *Enum) RandomIndex() int {
+	if len(e.weights) == 1 {
+		return 0
+	}
+	f := uniform(e.source)
+
+	// the first cumulative weight above f, so an index with zero weight is never drawn
+	i := 0
+	if len(e.weights) <= linearSize {
+		for _, w := range e.weights {
+			below := 0
+			if w <= f {
+				below = 1
+			}
+			i += below
+		}
+	} else {
+		i = sort.Search(len(e.weights), func(i int) bool {
+			return e.weights[i] > f
+		})
+	}
+
+	// the sum of weights may be slightly less than 1
+	if i == len(e.weights) {
+		i--
+	}
+	return i
+}
+
+// RandomUint8 returns the index as a uint8, for the enums with at most 256 codes.
+func (e *Enum) RandomUint8() uint8 {
+	return uint8(e.RandomIndex())
+}
@@ -0,0 +1,79 @@
+package discreteprobability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEnum(t *testing.T) {
+	weights := []float64{0.1, 0, 0.4, 0.2, 0.3}
+	e, err := NewEnum(len(weights), weights)
+	if err != nil {
+		t.Errorf("NewEnum error %v", err)
+		t.FailNow()
+	}
+	e.SetSeed(1)
+
+	occurrence := make([]float64, len(weights))
+	for i := 0; i < repeats; i++ {
+		occurrence[e.RandomUint8()]++
+	}
+	for i, w := range weights {
+		p := w * repeats
+		if d := p * 3 / 100; occurrence[i] > p+d || occurrence[i] < p-d {
+			t.Errorf("incorrect distribution index %v, expected %f, got %f", i, p, occurrence[i])
+		}
+	}
+}
+
+func TestEnumLarge(t *testing.T) {
+	weights := make([]float64, 100)
+	weights[42] = 0.5
+	weights[99] = 0.5
+	e, err := NewEnum(len(weights), weights)
+	if err != nil {
+		t.Errorf("NewEnum error %v", err)
+		t.FailNow()
+	}
+
+	for i := 0; i < 1000; i++ {
+		if v := e.RandomIndex(); v != 42 && v != 99 {
+			t.Errorf("unexpected index %v", v)
+			t.FailNow()
+		}
+	}
+}
+
+func TestNewEnumErrors(t *testing.T) {
+	if _, err := NewEnum(3, []float64{0.5, 0.5}); err != ErrLength {
+		t.Errorf("expected ErrLength, got %v", err)
+	}
+	if _, err := NewEnum(2, []float64{-0.5, 1.5}); err != ErrNegativeWeight {
+		t.Errorf("expected ErrNegativeWeight, got %v", err)
+	}
+	if _, err := NewEnum(2, []float64{0.5, 0.6}); err != ErrWeightSum {
+		t.Errorf("expected ErrWeightSum, got %v", err)
+	}
+	if _, err := NewEnum(2, []float64{math.NaN(), 1}); err != ErrWeightSum {
+		t.Errorf("expected ErrWeightSum, got %v", err)
+	}
+}
+
+func TestEnumScaled(t *testing.T) {
+	// within the tolerance the weights are scaled, so the last index doesn't take the rest
+	e, err := NewEnum(2, []float64{0.49995, 0.49995})
+	if err != nil {
+		t.Errorf("NewEnum error %v", err)
+		t.FailNow()
+	}
+	if e.weights[1] != 1 || e.weights[0] != 0.5 {
+		t.Errorf("expected the weights scaled to 1, got %v", e.weights)
+	}
+}
+
+func BenchmarkEnum(b *testing.B) {
+	e, _ := NewEnum(4, []float64{0.25, 0.25, 0.25, 0.25})
+	for n := 0; n < b.N; n++ {
+		e.RandomUint8()
+	}
+}
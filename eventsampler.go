@@ -0,0 +1,119 @@
+package discreteprobability
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// EventSampler downsamples a stream of events, e.g. of telemetry topics, by keeping an event
+// of a key with the sampling rate of the key. Each rate is a Generator of keep or drop, so it's
+// validated like any other weights. The rates can be updated while the events flow.
+// It's safe for concurrent use.
+type EventSampler struct {
+	mu          sync.Mutex
+	rates       map[string]*Generator
+	defaultRate *Generator
+	rnd         *rand.Rand
+}
+
+// NewEventSampler returns a new EventSampler with the rates by key, and defaultRate for the other keys.
+// It will return ErrProbability if a rate is out of range [0, 1]
+func NewEventSampler(rates map[string]float64, defaultRate float64) (*EventSampler, error) {
+	s := &EventSampler{
+		rates: make(map[string]*Generator, len(rates)),
+		rnd:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	var err error
+	if s.defaultRate, err = s.keep(defaultRate); err != nil {
+		return nil, err
+	}
+	for key, rate := range rates {
+		if s.rates[key], err = s.keep(rate); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// keep returns the Generator which keeps an event with the rate.
+func (s *EventSampler) keep(rate float64) (*Generator, error) {
+	if !(rate >= 0 && rate <= 1) {
+		return nil, ErrProbability
+	}
+	g, err := New([]bool{true, false}, []float64{rate, 1 - rate})
+	if err != nil {
+		return nil, err
+	}
+	g.SetSeed(s.rnd.Int63())
+	return g, nil
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (s *EventSampler) SetSeed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rnd = rand.New(rand.NewSource(seed))
+	s.defaultRate.SetSeed(s.rnd.Int63())
+	for _, g := range s.rates {
+		g.SetSeed(s.rnd.Int63())
+	}
+}
+
+// ShouldKeep returns whether to keep an event of the key, which is true with the rate of the key.
+func (s *EventSampler) ShouldKeep(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, ok := s.rates[key]
+	if !ok {
+		g = s.defaultRate
+	}
+	return g.random().Bool()
+}
+
+// ShouldKeepDefault returns whether to keep an event without a key, which is true with the default rate.
+func (s *EventSampler) ShouldKeepDefault() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.defaultRate.random().Bool()
+}
+
+// hasRate returns whether the key has its own rate.
+func (s *EventSampler) hasRate(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.rates[key]
+	return ok
+}
+
+// SetRate sets the rate of the key. It will return ErrProbability if the rate is out of range [0, 1],
+// and the old rate is kept in that case.
+func (s *EventSampler) SetRate(key string, rate float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, err := s.keep(rate)
+	if err != nil {
+		return err
+	}
+	s.rates[key] = g
+	return nil
+}
+
+// SetRates replaces all the rates, as a single update. It will return ErrProbability if a rate is out
+// of range [0, 1], and the old rates are kept in that case.
+func (s *EventSampler) SetRates(rates map[string]float64, defaultRate float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defaultGenerator, err := s.keep(defaultRate)
+	if err != nil {
+		return err
+	}
+	generators := make(map[string]*Generator, len(rates))
+	for key, rate := range rates {
+		if generators[key], err = s.keep(rate); err != nil {
+			return err
+		}
+	}
+	s.rates, s.defaultRate = generators, defaultGenerator
+	return nil
+}
@@ -0,0 +1,64 @@
+package discreteprobability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEventSampler(t *testing.T) {
+	s, err := NewEventSampler(map[string]float64{"debug": 0.1, "error": 1}, 0.5)
+	if err != nil {
+		t.Errorf("NewEventSampler error %v", err)
+		t.FailNow()
+	}
+	s.SetSeed(1)
+
+	kept := map[string]float64{}
+	for i := 0; i < repeats; i++ {
+		for _, key := range []string{"debug", "error", "info"} {
+			if s.ShouldKeep(key) {
+				kept[key]++
+			}
+		}
+	}
+	for key, rate := range map[string]float64{"debug": 0.1, "error": 1, "info": 0.5} {
+		p := rate * repeats
+		if d := p * 3 / 100; kept[key] > p+d || kept[key] < p-d {
+			t.Errorf("incorrect rate of %v, expected %f, got %f", key, p, kept[key])
+		}
+	}
+}
+
+func TestEventSamplerUpdate(t *testing.T) {
+	s, _ := NewEventSampler(map[string]float64{"debug": 1}, 1)
+	if err := s.SetRate("debug", 1.5); err != ErrProbability {
+		t.Errorf("expected ErrProbability, got %v", err)
+	}
+	if err := s.SetRates(map[string]float64{"debug": 0}, -1); err != ErrProbability {
+		t.Errorf("expected ErrProbability, got %v", err)
+	}
+	if !s.ShouldKeep("debug") || !s.ShouldKeep("info") {
+		t.Errorf("rates changed by a failed update")
+	}
+
+	s.SetRate("debug", 0)
+	s.SetRates(map[string]float64{"info": 1}, 0)
+	for i := 0; i < 100; i++ {
+		if s.ShouldKeep("debug") || !s.ShouldKeep("info") || s.ShouldKeepDefault() {
+			t.Errorf("unexpected decision after the update")
+			t.FailNow()
+		}
+	}
+}
+
+func TestNewEventSamplerErrors(t *testing.T) {
+	if _, err := NewEventSampler(nil, 2); err != ErrProbability {
+		t.Errorf("expected ErrProbability, got %v", err)
+	}
+	if _, err := NewEventSampler(map[string]float64{"debug": -0.1}, 1); err != ErrProbability {
+		t.Errorf("expected ErrProbability, got %v", err)
+	}
+	if _, err := NewEventSampler(map[string]float64{"debug": math.NaN()}, 1); err != ErrProbability {
+		t.Errorf("expected ErrProbability for NaN, got %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package discreteprobability
+
+import (
+	"math/big"
+	"math/rand"
+	"sort"
+)
+
+// exactTable stores the cumulative weights as integers over a common denominator,
+// so that a draw can be made without any rounding.
+type exactTable struct {
+	cumulative []*big.Int
+	total      *big.Int
+}
+
+func (e *exactTable) index(source rand.Source) int {
+	r := new(big.Int).Rand(rand.New(source), e.total)
+	return sort.Search(len(e.cumulative), func(i int) bool {
+		return e.cumulative[i].Cmp(r) > 0
+	})
+}
+
+// weight returns the weight of the value at index i over the common denominator.
+func (e *exactTable) weight(i int) *big.Int {
+	w := new(big.Int).Set(e.cumulative[i])
+	if i > 0 {
+		w.Sub(w, e.cumulative[i-1])
+	}
+	return w
+}
+
+// probability returns the weight of the value at index i without the rounding
+// of the cumulative sum, so tiny weights are kept.
+func (e *exactTable) probability(i int) float64 {
+	p, _ := new(big.Rat).SetFrac(e.weight(i), e.total).Float64()
+	return p
+}
+
+type exactSorter struct {
+	g *Generator
+	w []*big.Rat
+}
+
+func (s exactSorter) Len() int { return len(s.w) }
+func (s exactSorter) Swap(i, j int) {
+	s.g.values[i], s.g.values[j] = s.g.values[j], s.g.values[i]
+	s.w[i], s.w[j] = s.w[j], s.w[i]
+}
+func (s exactSorter) Less(i, j int) bool { return s.w[i].Cmp(s.w[j]) < 0 }
+
+// NewExact returns a new Generator with arbitrary-precision weights.
+// Draws are made by comparing big integers, so tiny weights like 1e-12 which
+// would be lost in a float64 cumulative sum still keep their exact probability.
+// It will return error if there are no values, values and weights have different length,
+// any weight is nil or negative, or the sum of weights is not exactly 1
+func NewExact(v interface{}, w []*big.Rat) (*Generator, error) {
+	values, err := sliceValues(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) != len(w) {
+		return nil, ErrLength
+	}
+	if len(values) == 0 {
+		return nil, ErrEmptyInput
+	}
+
+	weights := make([]*big.Rat, len(w))
+	for i, weight := range w {
+		if weight == nil {
+			return nil, ErrNilWeight
+		}
+		if weight.Sign() < 0 {
+			return nil, ErrNegativeWeight
+		}
+		weights[i] = new(big.Rat).Set(weight)
+	}
+
+	g := &Generator{
+		values:   values,
+		size:     len(values),
+		source:   rand.NewSource(seed),
+		tickSeed: seed,
+		order:    &valueOrder{},
+	}
+	sort.Sort(exactSorter{g: g, w: weights})
+
+	sum := new(big.Rat)
+	denominator := big.NewInt(1)
+	for _, weight := range weights {
+		sum.Add(sum, weight)
+		gcd := new(big.Int).GCD(nil, nil, denominator, weight.Denom())
+		denominator.Mul(denominator, new(big.Int).Quo(weight.Denom(), gcd))
+	}
+	if sum.Cmp(big.NewRat(1, 1)) != 0 {
+		return nil, ErrWeightSum
+	}
+
+	table := &exactTable{
+		cumulative: make([]*big.Int, len(weights)),
+		total:      denominator,
+	}
+	g.weights = make([]float64, len(weights))
+	acc := new(big.Int)
+	for i, weight := range weights {
+		n := new(big.Int).Quo(denominator, weight.Denom())
+		acc.Add(acc, n.Mul(n, weight.Num()))
+		table.cumulative[i] = new(big.Int).Set(acc)
+		g.weights[i], _ = new(big.Rat).SetFrac(acc, denominator).Float64()
+	}
+	g.exact = table
+
+	return g, nil
+}
@@ -0,0 +1,62 @@
+package discreteprobability
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestNewExactTinyWeight(t *testing.T) {
+	tiny := big.NewRat(1, 1000000000000)
+	rest := new(big.Rat).Sub(big.NewRat(1, 1), tiny)
+	g, err := NewExact([]string{"common", "rare"}, []*big.Rat{rest, tiny})
+	if err != nil {
+		t.Errorf("NewExact error %v", err)
+		t.FailNow()
+	}
+
+	if g.RandomString() != "common" {
+		t.Errorf("expected the common value to be drawn")
+		t.FailNow()
+	}
+	first := new(big.Rat).SetFrac(g.exact.cumulative[0], g.exact.total)
+	if first.Cmp(tiny) != 0 {
+		t.Errorf("expected exact weight %v, got %v", tiny, first)
+		t.FailNow()
+	}
+}
+
+func TestNewExactDistribution(t *testing.T) {
+	g, err := NewExact([]int{1, 2}, []*big.Rat{big.NewRat(1, 4), big.NewRat(3, 4)})
+	if err != nil {
+		t.Errorf("NewExact error %v", err)
+		t.FailNow()
+	}
+	g.SetSeed(1)
+
+	count := 0
+	for i := 0; i < repeats; i++ {
+		if g.RandomInt() == 1 {
+			count++
+		}
+	}
+	p := float64(repeats) / 4
+	if d := p * 3 / 100; float64(count) > p+d || float64(count) < p-d {
+		t.Errorf("incorrect distribution, expected %f, got %d", p, count)
+		t.FailNow()
+	}
+}
+
+func TestNewExactErrors(t *testing.T) {
+	if _, err := NewExact([]int{1, 2}, []*big.Rat{big.NewRat(1, 2)}); err != ErrLength {
+		t.Errorf("expected ErrLength, got %v", err)
+	}
+	if _, err := NewExact([]int{1, 2}, []*big.Rat{big.NewRat(1, 2), big.NewRat(1, 3)}); err != ErrWeightSum {
+		t.Errorf("expected ErrWeightSum, got %v", err)
+	}
+	if _, err := NewExact([]int{1, 2}, []*big.Rat{big.NewRat(-1, 2), big.NewRat(3, 2)}); err != ErrNegativeWeight {
+		t.Errorf("expected ErrNegativeWeight, got %v", err)
+	}
+	if _, err := NewExact([]int{1, 2}, []*big.Rat{big.NewRat(1, 1), nil}); err != ErrNilWeight {
+		t.Errorf("expected ErrNilWeight, got %v", err)
+	}
+}
@@ -0,0 +1,18 @@
+package discreteprobability
+
+// WithExploration returns a new Generator which draws with the weights of g with
+// the probability 1-eps, and uniformly with the probability eps, so low-weight values
+// are never starved. It's the mixture (1-eps)*p + eps/n of the weights.
+// The random stream of the new Generator is seeded from g.
+// It will return ErrProbability if eps is out of range [0, 1]
+func (g *Generator) WithExploration(eps float64) (*Generator, error) {
+	if eps < 0 || eps > 1 {
+		return nil, ErrProbability
+	}
+
+	weights := make([]float64, g.size)
+	for i := range weights {
+		weights[i] = (1-eps)*g.probability(i) + eps/float64(g.size)
+	}
+	return g.derive(weights)
+}
@@ -0,0 +1,19 @@
+package discreteprobability
+
+import "testing"
+
+func TestWithExploration(t *testing.T) {
+	g, _ := New([]string{"a", "b", "c", "d"}, []float64{1, 0, 0, 0})
+	e, err := g.WithExploration(0.2)
+	if err != nil {
+		t.Errorf("WithExploration error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(e, "a", 0.85) || !weightEqual(e, "b", 0.05) || !weightEqual(e, "d", 0.05) {
+		t.Errorf("unexpected generator %v", e)
+	}
+
+	if _, err := g.WithExploration(1.5); err != ErrProbability {
+		t.Errorf("expected ErrProbability, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package discreteprobability
+
+import (
+	"math"
+	"math/bits"
+)
+
+// The cumulative weights are also stored in the Eytzinger layout for the generators with
+// eytzingerMinSize to eytzingerMaxSize values. See BenchmarkSearchLayout for the crossover,
+// around a million values the tree no longer fits the cache and the binary search wins again.
+const (
+	eytzingerMinSize = 1 << 10
+	eytzingerMaxSize = 1 << 19
+)
+
+// eytzinger stores the cumulative weights in the BFS order of a perfect binary search
+// tree, so the first levels of every search share the same cache lines. The tree is
+// padded with +Inf, and index 0 is unused.
+type eytzinger []float64
+
+// newEytzinger returns the Eytzinger layout of the sorted cumulative weights.
+func newEytzinger(cumulative []float64) eytzinger {
+	height := bits.Len(uint(len(cumulative)))
+	e := make(eytzinger, 1<<uint(height))
+	e.build(cumulative, 0, 1)
+	return e
+}
+
+// build fills the subtree rooted at k with the in-order values starting at i,
+// and returns the next in-order position.
+func (e eytzinger) build(cumulative []float64, i int, k int) int {
+	if k < len(e) {
+		i = e.build(cumulative, i, 2*k)
+		if i < len(cumulative) {
+			e[k] = cumulative[i]
+		} else {
+			e[k] = math.Inf(1)
+		}
+		i++
+		i = e.build(cumulative, i, 2*k+1)
+	}
+	return i
+}
+
+// search returns the index of the first cumulative weight which covers f,
+// or size if there is none.
+func (e eytzinger) search(f float64, size int) int {
+	height := bits.Len(uint(len(e))) - 1
+	k := 1
+	touched := float64(0)
+	for level := 0; level < height; level++ {
+		// touch the node four levels below early, the load doesn't depend on
+		// this comparison so it overlaps with it like a prefetch
+		touched += e[(16*k)&(len(e)-1)]
+		below := 0
+		if e[k] < f {
+			below = 1
+		}
+		k = 2*k + below
+	}
+	// never true for valid weights, it only keeps the prefetching loads
+	if math.IsNaN(touched) {
+		return size
+	}
+
+	// go back up past the right turns, to the last node which was not below f
+	k >>= uint(bits.TrailingZeros(^uint(k))) + 1
+	if k == 0 {
+		return size
+	}
+
+	// in-order rank of the node in a perfect tree
+	depth := bits.Len(uint(k)) - 1
+	rank := (2*(k-1<<uint(depth))+1)<<uint(height-1-depth) - 1
+	if rank > size {
+		return size
+	}
+	return rank
+}
@@ -0,0 +1,69 @@
+package discreteprobability
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestEytzinger(t *testing.T) {
+	for _, size := range []int{1, 2, 7, 100, 1023, 1024} {
+		g := generateInt(t, time.Now().Unix(), size)
+		e := newEytzinger(g.weights)
+		for i := 0; i < repeats/10; i++ {
+			f := uniform(g.source)
+			if i == 0 {
+				f = 2
+			}
+			expected := sort.Search(g.size, func(i int) bool {
+				return g.weights[i] >= f
+			})
+			if got := e.search(f, g.size); got != expected {
+				t.Errorf("size %v search of %v expected %v, got %v", size, f, expected, got)
+				t.FailNow()
+			}
+		}
+	}
+}
+
+func TestEytzingerLayout(t *testing.T) {
+	// increasing weights, uniform weights are drawn without any search
+	values := make([]int, eytzingerMinSize)
+	weights := make([]float64, eytzingerMinSize)
+	for i := range values {
+		values[i] = i
+		weights[i] = float64(i+1) / (eytzingerMinSize * (eytzingerMinSize + 1) / 2)
+	}
+	g, err := New(values, weights)
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+	if g.layout == nil {
+		t.Errorf("expected the Eytzinger layout for %v values", eytzingerMinSize)
+	}
+	if v := g.RandomInt(); v < 0 || v >= eytzingerMinSize {
+		t.Errorf("unexpected value %v", v)
+	}
+}
+
+func BenchmarkSearchLayout(b *testing.B) {
+	for size := 1000; size <= 10000000; size *= 10 {
+		g := generateInt(nil, 1, size)
+		e := newEytzinger(g.weights)
+		b.Run(fmt.Sprintf("Binary_size_%d", size), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				f := uniform(g.source)
+				sort.Search(g.size, func(i int) bool {
+					return g.weights[i] >= f
+				})
+			}
+		})
+		b.Run(fmt.Sprintf("Eytzinger_size_%d", size), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				e.search(uniform(g.source), g.size)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Package faults injects weighted faults for chaos engineering.
+// Example usage:
+//
+//		injector, err := faults.NewInjector(map[faults.Fault]float64{
+//			faults.Latency: 0.05,
+//			faults.Error:   0.01,
+//		})
+//		if err != nil {
+//			panic(err) // Error handlers
+//		}
+//		http.Handle("/", injector.Middleware(handler))
+//
+//		5% of the requests are delayed, 1% fail with 503 and the rest are untouched.
+package faults
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+// Fault is a kind of fault to inject
+type Fault int
+
+const (
+	// None injects nothing
+	None Fault = iota
+	// Latency delays the request
+	Latency
+	// Error responds with an error status code
+	Error
+	// Abort aborts the connection without a response
+	Abort
+)
+
+func (f Fault) String() string {
+	switch f {
+	case None:
+		return "none"
+	case Latency:
+		return "latency"
+	case Error:
+		return "error"
+	case Abort:
+		return "abort"
+	}
+	return "unknown"
+}
+
+// ErrRate is returned when the sum of the fault rates is greater than 1
+var ErrRate = errors.New("sum of fault rates is greater than 1")
+
+type disabledKey struct{}
+
+// Injector draws faults with the configured rates. It's safe for concurrent use.
+type Injector struct {
+	// Delay is the delay of a Latency fault, 1 second by default
+	Delay time.Duration
+	// StatusCode is the status code of an Error fault, 503 by default
+	StatusCode int
+
+	mu sync.Mutex
+	g  *discreteprobability.Generator
+}
+
+// NewInjector returns a new Injector. The rates are the probabilities of each fault,
+// and None takes the rest of the probability if it's not in the rates.
+// It will return ErrRate if the sum of rates is greater than 1
+func NewInjector(rates map[Fault]float64) (*Injector, error) {
+	faults := make([]Fault, 0, len(rates)+1)
+	sum := float64(0)
+	for fault, rate := range rates {
+		faults = append(faults, fault)
+		sum += rate
+	}
+	if sum > 1+1e-9 {
+		return nil, ErrRate
+	}
+	if _, ok := rates[None]; !ok {
+		faults = append(faults, None)
+	}
+	// map iteration order is random, sort the faults so a seeded injector is reproducible
+	sort.Slice(faults, func(i, j int) bool { return faults[i] < faults[j] })
+
+	weights := make([]float64, len(faults))
+	for i, fault := range faults {
+		weights[i] = rates[fault]
+		if _, ok := rates[None]; !ok && fault == None && sum < 1 {
+			weights[i] = 1 - sum
+		}
+	}
+
+	g, err := discreteprobability.New(faults, weights)
+	if err != nil {
+		return nil, err
+	}
+	return &Injector{
+		Delay:      time.Second,
+		StatusCode: http.StatusServiceUnavailable,
+		g:          g,
+	}, nil
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (in *Injector) SetSeed(s int64) {
+	in.mu.Lock()
+	in.g.SetSeed(s)
+	in.mu.Unlock()
+}
+
+// Disable returns a context in which Maybe always returns None,
+// e.g. for health checks which should never fail.
+func Disable(ctx context.Context) context.Context {
+	return context.WithValue(ctx, disabledKey{}, true)
+}
+
+// Maybe returns the fault to inject, None if the faults are disabled in ctx.
+func (in *Injector) Maybe(ctx context.Context) Fault {
+	if disabled, _ := ctx.Value(disabledKey{}).(bool); disabled {
+		return None
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return Fault(in.g.RandomInt())
+}
+
+// Middleware injects the faults into the requests to next. A Latency fault delays the
+// request unless it's canceled, an Error fault responds with StatusCode, and an
+// Abort fault aborts the connection with http.ErrAbortHandler.
+func (in *Injector) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch in.Maybe(r.Context()) {
+		case Latency:
+			timer := time.NewTimer(in.Delay)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-r.Context().Done():
+				return
+			}
+		case Error:
+			http.Error(w, http.StatusText(in.StatusCode), in.StatusCode)
+			return
+		case Abort:
+			panic(http.ErrAbortHandler)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,72 @@
+package faults
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const repeats = 100000
+
+func TestMaybe(t *testing.T) {
+	in, err := NewInjector(map[Fault]float64{Latency: 0.2, Error: 0.1})
+	if err != nil {
+		t.Errorf("NewInjector error %v", err)
+		t.FailNow()
+	}
+	in.SetSeed(time.Now().Unix())
+
+	occurrence := map[Fault]float64{}
+	for i := 0; i < repeats; i++ {
+		occurrence[in.Maybe(context.Background())]++
+	}
+	for fault, rate := range map[Fault]float64{None: 0.7, Latency: 0.2, Error: 0.1, Abort: 0} {
+		p := rate * repeats
+		if d := p * 5 / 100; occurrence[fault] > p+d || occurrence[fault] < p-d {
+			t.Errorf("incorrect rate of %v, expected %f, got %f", fault, p, occurrence[fault])
+		}
+	}
+
+	if f := in.Maybe(Disable(context.Background())); f != None {
+		t.Errorf("expected no fault in disabled context, got %v", f)
+	}
+}
+
+func TestNewInjectorRate(t *testing.T) {
+	if _, err := NewInjector(map[Fault]float64{Latency: 0.6, Error: 0.6}); err != ErrRate {
+		t.Errorf("expected ErrRate, got %v", err)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	in, _ := NewInjector(map[Fault]float64{Error: 1})
+	in.StatusCode = http.StatusTeapot
+	w := httptest.NewRecorder()
+	in.Middleware(ok).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusTeapot {
+		t.Errorf("expected injected error, got %v", w.Code)
+	}
+
+	in, _ = NewInjector(map[Fault]float64{Latency: 1})
+	in.Delay = 10 * time.Millisecond
+	w = httptest.NewRecorder()
+	start := time.Now()
+	in.Middleware(ok).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusOK || time.Since(start) < in.Delay {
+		t.Errorf("expected delayed success, got %v after %v", w.Code, time.Since(start))
+	}
+
+	in, _ = NewInjector(map[Fault]float64{Abort: 1})
+	defer func() {
+		if r := recover(); r != http.ErrAbortHandler {
+			t.Errorf("expected abort, got %v", r)
+		}
+	}()
+	in.Middleware(ok).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
@@ -0,0 +1,221 @@
+// Package flagprovider evaluates feature flags in-process with weighted variants, as an
+// OpenFeature provider. A targeting key always gets the same variant of a flag, by rendezvous
+// hashing, so a user stays in the same bucket and only the users of a changed share move.
+// The package doesn't depend on the OpenFeature SDK, the methods have the shapes of its
+// FeatureProvider with the SDK types replaced by the local ones, so the adapter is a thin
+// wrapper of each method, e.g.
+//
+//		func (a adapter) StringEvaluation(ctx context.Context, flag string, defaultValue string,
+//			evalCtx openfeature.FlattenedContext) openfeature.StringResolutionDetail {
+//			d := a.p.StringEvaluation(ctx, flag, defaultValue, evalCtx)
+//			return openfeature.StringResolutionDetail{
+//				Value: d.Value.(string),
+//				ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+//					ResolutionError: d.ResolutionError(),
+//					Reason:          openfeature.Reason(d.Reason),
+//					Variant:         d.Variant,
+//				},
+//			}
+//		}
+//
+// Example usage:
+//
+//		p, err := flagprovider.New(map[string]flagprovider.Flag{
+//			"new-checkout": {
+//				Variants: map[string]interface{}{"on": true, "off": false},
+//				Weights:  map[string]float64{"on": 10, "off": 90},
+//			},
+//		})
+//		if err != nil {
+//			panic(err) // Error handlers
+//		}
+//		d := p.BooleanEvaluation(ctx, "new-checkout", false, map[string]interface{}{"targetingKey": userID})
+//
+//		10% of the users get the new checkout.
+package flagprovider
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+// ErrVariant is returned when a flag has a weight of a variant which is not defined
+var ErrVariant = errors.New("weight of an undefined variant")
+
+// TargetingKey is the key of the evaluation context of OpenFeature which identifies the subject.
+const TargetingKey = "targetingKey"
+
+// The reasons and error codes are the ones of OpenFeature.
+const (
+	// ReasonSplit is the reason of a variant drawn with the weights
+	ReasonSplit = "SPLIT"
+	// ReasonDefault is the reason of the default value of a disabled flag
+	ReasonDefault = "DEFAULT"
+	// ReasonError is the reason of the default value when the evaluation failed
+	ReasonError = "ERROR"
+
+	// ErrorFlagNotFound is the error code of an unknown flag
+	ErrorFlagNotFound = "FLAG_NOT_FOUND"
+	// ErrorTypeMismatch is the error code of a variant of another type than the evaluation
+	ErrorTypeMismatch = "TYPE_MISMATCH"
+)
+
+// Flag is a feature flag with weighted variants.
+type Flag struct {
+	// Variants are the values of the flag by the variant name
+	Variants map[string]interface{}
+	// Weights are the relative weights of the variants, e.g. percentages. A variant without
+	// a weight is never drawn
+	Weights map[string]float64
+	// Disabled flags evaluate to the default value
+	Disabled bool
+}
+
+// flag is a Flag with the generator of its variant names.
+type flag struct {
+	Flag
+	g *discreteprobability.Generator
+}
+
+// ResolutionDetail is the result of an evaluation.
+type ResolutionDetail struct {
+	Value     interface{}
+	Variant   string
+	Reason    string
+	ErrorCode string
+}
+
+// ResolutionError returns the error of the evaluation, or nil if it succeeded.
+func (d ResolutionDetail) ResolutionError() error {
+	if d.ErrorCode == "" {
+		return nil
+	}
+	return errors.New(d.ErrorCode)
+}
+
+// Metadata is the metadata of the provider.
+type Metadata struct {
+	Name string
+}
+
+// Provider evaluates the flags. It's safe for concurrent use.
+type Provider struct {
+	mu    sync.Mutex
+	flags map[string]flag
+}
+
+// New returns a new Provider of the flags. It will return ErrVariant if a weight is of an undefined
+// variant, or the error of discreteprobability.NewNormalized if the weights of a flag are invalid
+func New(flags map[string]Flag) (*Provider, error) {
+	p := &Provider{flags: make(map[string]flag, len(flags))}
+	for key, f := range flags {
+		names := make([]string, 0, len(f.Weights))
+		for name := range f.Weights {
+			if _, ok := f.Variants[name]; !ok {
+				return nil, ErrVariant
+			}
+			names = append(names, name)
+		}
+		// the order of the values doesn't change the rendezvous hashing, only the random draws
+		sort.Strings(names)
+		weights := make([]float64, len(names))
+		for i, name := range names {
+			weights[i] = f.Weights[name]
+		}
+
+		g, err := discreteprobability.NewNormalized(names, weights)
+		if err != nil {
+			return nil, err
+		}
+		p.flags[key] = flag{Flag: f, g: g}
+	}
+	return p, nil
+}
+
+// Metadata returns the name of the provider.
+func (p *Provider) Metadata() Metadata {
+	return Metadata{Name: "discreteprobability"}
+}
+
+// SetSeed is to set a custom random seed other than the time stamp, for the evaluations without targeting key.
+func (p *Provider) SetSeed(s int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, f := range p.flags {
+		f.g.SetSeed(s)
+	}
+}
+
+// Evaluate returns the variant of the flag. With a string targeting key in evalCtx the variant is
+// the same for every evaluation of the key, without one it's drawn at random.
+func (p *Provider) Evaluate(key string, defaultValue interface{}, evalCtx map[string]interface{}) ResolutionDetail {
+	f, ok := p.flags[key]
+	if !ok {
+		return ResolutionDetail{Value: defaultValue, Reason: ReasonError, ErrorCode: ErrorFlagNotFound}
+	}
+	if f.Disabled {
+		return ResolutionDetail{Value: defaultValue, Reason: ReasonDefault}
+	}
+
+	var variant string
+	if target, ok := evalCtx[TargetingKey].(string); ok {
+		// the flag key keeps the buckets of the flags independent
+		variant = f.g.RendezvousPick(key + "\x00" + target).(string)
+	} else {
+		p.mu.Lock()
+		variant = f.g.RandomString()
+		p.mu.Unlock()
+	}
+	return ResolutionDetail{Value: f.Variants[variant], Variant: variant, Reason: ReasonSplit}
+}
+
+// evaluate returns the evaluation of the flag, or the default value with ErrorTypeMismatch
+// if the value is not accepted by ok.
+func (p *Provider) evaluate(key string, defaultValue interface{}, evalCtx map[string]interface{}, ok func(interface{}) bool) ResolutionDetail {
+	d := p.Evaluate(key, defaultValue, evalCtx)
+	if d.ErrorCode == "" && !ok(d.Value) {
+		return ResolutionDetail{Value: defaultValue, Variant: d.Variant, Reason: ReasonError, ErrorCode: ErrorTypeMismatch}
+	}
+	return d
+}
+
+// BooleanEvaluation returns the evaluation of a flag with bool variants.
+func (p *Provider) BooleanEvaluation(ctx context.Context, key string, defaultValue bool, evalCtx map[string]interface{}) ResolutionDetail {
+	return p.evaluate(key, defaultValue, evalCtx, func(v interface{}) bool {
+		_, ok := v.(bool)
+		return ok
+	})
+}
+
+// StringEvaluation returns the evaluation of a flag with string variants.
+func (p *Provider) StringEvaluation(ctx context.Context, key string, defaultValue string, evalCtx map[string]interface{}) ResolutionDetail {
+	return p.evaluate(key, defaultValue, evalCtx, func(v interface{}) bool {
+		_, ok := v.(string)
+		return ok
+	})
+}
+
+// FloatEvaluation returns the evaluation of a flag with float64 variants.
+func (p *Provider) FloatEvaluation(ctx context.Context, key string, defaultValue float64, evalCtx map[string]interface{}) ResolutionDetail {
+	return p.evaluate(key, defaultValue, evalCtx, func(v interface{}) bool {
+		_, ok := v.(float64)
+		return ok
+	})
+}
+
+// IntEvaluation returns the evaluation of a flag with int64 variants.
+func (p *Provider) IntEvaluation(ctx context.Context, key string, defaultValue int64, evalCtx map[string]interface{}) ResolutionDetail {
+	return p.evaluate(key, defaultValue, evalCtx, func(v interface{}) bool {
+		_, ok := v.(int64)
+		return ok
+	})
+}
+
+// ObjectEvaluation returns the evaluation of a flag with variants of any type.
+func (p *Provider) ObjectEvaluation(ctx context.Context, key string, defaultValue interface{}, evalCtx map[string]interface{}) ResolutionDetail {
+	return p.Evaluate(key, defaultValue, evalCtx)
+}
@@ -0,0 +1,75 @@
+package flagprovider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func newProvider(t *testing.T) *Provider {
+	p, err := New(map[string]Flag{
+		"checkout": {
+			Variants: map[string]interface{}{"on": true, "off": false},
+			Weights:  map[string]float64{"on": 10, "off": 90},
+		},
+		"color": {
+			Variants: map[string]interface{}{"red": "#f00", "blue": "#00f"},
+			Weights:  map[string]float64{"red": 1, "blue": 1},
+			Disabled: true,
+		},
+	})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+	return p
+}
+
+func TestBooleanEvaluation(t *testing.T) {
+	p := newProvider(t)
+	ctx := context.Background()
+	on := 0
+	for i := 0; i < 10000; i++ {
+		evalCtx := map[string]interface{}{TargetingKey: fmt.Sprint("user", i)}
+		d := p.BooleanEvaluation(ctx, "checkout", false, evalCtx)
+		if d.Reason != ReasonSplit || d.ResolutionError() != nil {
+			t.Errorf("unexpected evaluation %+v", d)
+			t.FailNow()
+		}
+		// sticky for the targeting key
+		if again := p.BooleanEvaluation(ctx, "checkout", false, evalCtx); again.Value != d.Value {
+			t.Errorf("user%v got %v and %v", i, d.Value, again.Value)
+			t.FailNow()
+		}
+		if d.Value.(bool) {
+			on++
+		}
+	}
+	if on < 900 || on > 1100 {
+		t.Errorf("expected about 1000 users on, got %v", on)
+	}
+}
+
+func TestEvaluationErrors(t *testing.T) {
+	p := newProvider(t)
+	p.SetSeed(1)
+	ctx := context.Background()
+	if d := p.BooleanEvaluation(ctx, "missing", true, nil); d.Value != true || d.ErrorCode != ErrorFlagNotFound {
+		t.Errorf("unexpected evaluation %+v", d)
+	}
+	if d := p.StringEvaluation(ctx, "checkout", "x", nil); d.Value != "x" || d.ErrorCode != ErrorTypeMismatch {
+		t.Errorf("unexpected evaluation %+v", d)
+	}
+	if d := p.StringEvaluation(ctx, "color", "#000", nil); d.Value != "#000" || d.Reason != ReasonDefault {
+		t.Errorf("unexpected evaluation %+v", d)
+	}
+	if d := p.ObjectEvaluation(ctx, "checkout", nil, nil); d.Value == nil || d.Variant == "" {
+		t.Errorf("unexpected evaluation %+v", d)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	if _, err := New(map[string]Flag{"f": {Weights: map[string]float64{"on": 1}}}); err != ErrVariant {
+		t.Errorf("expected ErrVariant, got %v", err)
+	}
+}
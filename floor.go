@@ -0,0 +1,74 @@
+package discreteprobability
+
+// WithFloor returns a new Generator in which every value has at least the probability pmin,
+// e.g. every ad gets at least 1% of the impressions. The values below pmin are raised to it
+// and the others are scaled down proportionally to make up for it, so a larger weight gives
+// more. The random stream of the new Generator is seeded from g.
+// It will return ErrProbability if pmin is negative or larger than 1/n for n values
+func (g *Generator) WithFloor(pmin float64) (*Generator, error) {
+	if !(pmin >= 0 && pmin*float64(g.size) <= 1) {
+		return nil, ErrProbability
+	}
+
+	masses, bounds := make([]float64, g.size), make([]float64, g.size)
+	for i := range masses {
+		masses[i], bounds[i] = g.probability(i), pmin
+	}
+	floored, scale, err := pin(masses, bounds, false)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make([]float64, g.size)
+	for i, f := range floored {
+		weights[i] = g.probability(i) * scale
+		if f {
+			weights[i] = pmin
+		}
+	}
+	if weights, err = normalize(weights); err != nil {
+		return nil, err
+	}
+	return g.derive(weights)
+}
+
+// pin returns which of the masses are pinned to their bounds, and the scale of the others
+// to make all of them sum to 1. With above the masses scaled above their bounds are pinned,
+// otherwise the ones scaled below. Scaling the others may push more of them past their
+// bounds, so it's repeated until no more are pinned.
+// It will return ErrNotEnough if the others have no mass to take the rest
+func pin(masses, bounds []float64, above bool) ([]bool, float64, error) {
+	pinned := make([]bool, len(masses))
+	for {
+		// the pinned masses take their bounds, the others share the rest of the mass
+		left, rest := float64(1), float64(0)
+		for i, p := range pinned {
+			if p {
+				left -= bounds[i]
+			} else {
+				rest += masses[i]
+			}
+		}
+		if rest <= 0 && left > weightEpsilon {
+			return nil, 0, ErrNotEnough
+		}
+		scale := float64(0)
+		if rest > 0 {
+			scale = left / rest
+		}
+
+		changed := false
+		for i, p := range pinned {
+			if p {
+				continue
+			}
+			if scaled := masses[i] * scale; above && scaled > bounds[i] || !above && scaled < bounds[i] {
+				pinned[i] = true
+				changed = true
+			}
+		}
+		if !changed {
+			return pinned, scale, nil
+		}
+	}
+}
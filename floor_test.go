@@ -0,0 +1,50 @@
+package discreteprobability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWithFloor(t *testing.T) {
+	g, err := New([]string{"a", "b", "c", "d"}, []float64{0, 0.005, 0.395, 0.6})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+
+	f, err := g.WithFloor(0.1)
+	if err != nil {
+		t.Errorf("WithFloor error %v", err)
+		t.FailNow()
+	}
+	// c and d share the remaining 0.8 in the ratio 0.395:0.6
+	if !weightEqual(f, "a", 0.1) || !weightEqual(f, "b", 0.1) ||
+		!weightEqual(f, "c", 0.8*0.395/0.995) || !weightEqual(f, "d", 0.8*0.6/0.995) {
+		t.Errorf("unexpected generator %v", f)
+	}
+
+	// raising a scales b down below the floor too
+	g, err = New([]string{"a", "b", "c"}, []float64{0, 0.3, 0.7})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+	f, err = g.WithFloor(0.3)
+	if err != nil {
+		t.Errorf("WithFloor error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(f, "a", 0.3) || !weightEqual(f, "b", 0.3) || !weightEqual(f, "c", 0.4) {
+		t.Errorf("unexpected generator %v", f)
+	}
+
+	if _, err := g.WithFloor(0.5); err != ErrProbability {
+		t.Errorf("expected ErrProbability, got %v", err)
+	}
+	if _, err := g.WithFloor(-0.1); err != ErrProbability {
+		t.Errorf("expected ErrProbability, got %v", err)
+	}
+	if _, err := g.WithFloor(math.NaN()); err != ErrProbability {
+		t.Errorf("expected ErrProbability for NaN, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package discreteprobability
+
+import (
+	"math/rand"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Frozen is an immutable sampler which is safe for concurrent use,
+// e.g. stored in a package-level variable. Its only state is the randomness,
+// either passed in to RandomFrom or taken from a pool of sources.
+type Frozen struct {
+	g    *Generator
+	pool sync.Pool
+}
+
+// sourceSeed makes the seeds of the pooled sources distinct.
+var sourceSeed = time.Now().UnixNano()
+
+// Freeze returns a Frozen copy of the generator. Later changes of g don't affect it.
+func (g *Generator) Freeze() *Frozen {
+	f := &Frozen{g: g.CloneWithSeed(0)}
+	f.g.source = nil
+	f.pool.New = func() interface{} {
+		return rand.NewSource(atomic.AddInt64(&sourceSeed, 1))
+	}
+	return f
+}
+
+func (f *Frozen) random() reflect.Value {
+	source := f.pool.Get().(rand.Source)
+	i := f.g.pick(source)
+	f.pool.Put(source)
+	f.g.observe(i, false)
+	return f.g.values[i]
+}
+
+// Random returns the value from the value set with corresponding weights,
+// with a source from the pool.
+func (f *Frozen) Random() interface{} {
+	return f.random().Interface()
+}
+
+// RandomFrom returns the value drawn with the randomness of source,
+// which is owned by the caller.
+func (f *Frozen) RandomFrom(source rand.Source) interface{} {
+	i := f.g.pick(source)
+	f.g.observe(i, false)
+	return f.g.values[i].Interface()
+}
+
+// RandomInt returns the int value without type assertion, see Generator.RandomInt
+func (f *Frozen) RandomInt() int {
+	return int(f.random().Int())
+}
+
+// RandomFloat64 returns the float64 value without type assertion, see Generator.RandomFloat64
+func (f *Frozen) RandomFloat64() float64 {
+	return f.random().Float()
+}
+
+// RandomString returns the string value without type assertion, see Generator.RandomString
+func (f *Frozen) RandomString() string {
+	return f.random().String()
+}
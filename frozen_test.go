@@ -0,0 +1,52 @@
+package discreteprobability
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFreeze(t *testing.T) {
+	g := generateInt(t, time.Now().Unix(), sliceLen)
+	f := g.Freeze()
+	g.weights[0] = 1
+
+	var wg sync.WaitGroup
+	occurrence := make([]map[int]float64, 8)
+	for n := range occurrence {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			occurrence[n] = map[int]float64{}
+			for i := 0; i < repeats/8; i++ {
+				occurrence[n][f.RandomInt()]++
+			}
+		}(n)
+	}
+	wg.Wait()
+
+	total := map[int]float64{}
+	for _, o := range occurrence {
+		for v, count := range o {
+			total[v] += count
+		}
+	}
+	p := float64(repeats/8*8) / sliceLen
+	for v := 0; v < sliceLen; v++ {
+		if d := p * 5 / 100; total[v] > p+d || total[v] < p-d {
+			t.Errorf("incorrect distribution value %v, expected %f, got %f", v, p, total[v])
+		}
+	}
+}
+
+func TestFrozenRandomFrom(t *testing.T) {
+	f := generateString(t, 1, sliceLen).Freeze()
+	a, b := rand.NewSource(0), rand.NewSource(0)
+	for i := 0; i < sliceLen; i++ {
+		if f.RandomFrom(a) != f.RandomFrom(b) {
+			t.Errorf("same source got different values")
+			t.FailNow()
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package discreteprobability
+
+import "reflect"
+
+// WeightedEdge is an edge to the node To. The weights of the edges of a node
+// don't need to sum to 1.
+type WeightedEdge struct {
+	To     interface{}
+	Weight float64
+}
+
+var edgesType = reflect.TypeOf([]WeightedEdge(nil))
+
+// RandomPair returns two distinct nodes drawn by the node weights, the second one
+// is drawn from the rest of the nodes. It will return ErrNotEnough if there are
+// less than two nodes with positive weights.
+func (g *Generator) RandomPair() (interface{}, interface{}, error) {
+	buf := g.getPicked(g.size)
+	defer g.putPicked(buf)
+	picked := *buf
+	all := func(int) bool { return true }
+
+	first := g.drawWithout(picked, all)
+	if first < 0 {
+		return nil, nil, ErrNotEnough
+	}
+	picked[first] = true
+	second := g.drawWithout(picked, all)
+	if second < 0 {
+		return nil, nil, ErrNotEnough
+	}
+	return g.values[first].Interface(), g.values[second].Interface(), nil
+}
+
+// RandomNeighbor draws a node by the node weights, then follows one of its edges in adj
+// by the edge weights. The adj should be a map from the node to its edges,
+// e.g. map[string][]WeightedEdge. It will return ErrNotEnough if the drawn node
+// has no edges with positive weights.
+func (g *Generator) RandomNeighbor(adj interface{}) (interface{}, interface{}, error) {
+	val := reflect.ValueOf(adj)
+	if val.Kind() != reflect.Map {
+		return nil, nil, ErrNotMap
+	}
+	if val.Type().Elem() != edgesType {
+		return nil, nil, ErrType
+	}
+
+	node := g.random()
+	if !node.Type().AssignableTo(val.Type().Key()) {
+		return nil, nil, ErrType
+	}
+	var edges []WeightedEdge
+	if v := val.MapIndex(node); v.IsValid() {
+		edges = v.Interface().([]WeightedEdge)
+	}
+	i := pickEdge(g, edges)
+	if i < 0 {
+		return nil, nil, ErrNotEnough
+	}
+	return node.Interface(), edges[i].To, nil
+}
+
+// pickEdge returns the index of an edge drawn by the edge weights with the source of g,
+// or -1 if none of the edges has a positive weight.
+func pickEdge(g *Generator, edges []WeightedEdge) int {
+	sum := float64(0)
+	for _, edge := range edges {
+		if edge.Weight > 0 {
+			sum += edge.Weight
+		}
+	}
+	if sum <= 0 {
+		return -1
+	}
+
+	f := uniform(g.source) * sum
+	last := -1
+	for i, edge := range edges {
+		if edge.Weight <= 0 {
+			continue
+		}
+		last = i
+		if f < edge.Weight {
+			return i
+		}
+		f -= edge.Weight
+	}
+	return last
+}
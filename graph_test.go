@@ -0,0 +1,64 @@
+package discreteprobability
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRandomPair(t *testing.T) {
+	g := generateInt(t, time.Now().Unix(), sliceLen)
+	for i := 0; i < repeats/10; i++ {
+		a, b, err := g.RandomPair()
+		if err != nil {
+			t.Errorf("RandomPair error %v", err)
+			t.FailNow()
+		}
+		if a == b {
+			t.Errorf("expected distinct nodes, got %v and %v", a, b)
+			t.FailNow()
+		}
+	}
+
+	single, _ := New([]int{1, 2}, []float64{1, 0})
+	if _, _, err := single.RandomPair(); err != ErrNotEnough {
+		t.Errorf("expected ErrNotEnough, got %v", err)
+	}
+}
+
+func TestRandomNeighbor(t *testing.T) {
+	g, _ := New([]string{"a", "b"}, []float64{0.5, 0.5})
+	g.SetSeed(time.Now().Unix())
+	adj := map[string][]WeightedEdge{
+		"a": {{To: "b", Weight: 3}, {To: "c", Weight: 1}},
+		"b": {{To: "a", Weight: 1}},
+	}
+
+	count := 0
+	for i := 0; i < repeats; i++ {
+		from, to, err := g.RandomNeighbor(adj)
+		if err != nil {
+			t.Errorf("RandomNeighbor error %v", err)
+			t.FailNow()
+		}
+		switch {
+		case from == "a" && to == "b":
+			count++
+		case from == "a" && to == "c", from == "b" && to == "a":
+		default:
+			t.Errorf("unexpected edge %v -> %v", from, to)
+			t.FailNow()
+		}
+	}
+
+	p := float64(repeats) * 0.5 * 0.75
+	if d := p * 3 / 100; float64(count) > p+d || float64(count) < p-d {
+		t.Errorf("incorrect edge distribution, expected %f, got %d", p, count)
+	}
+
+	if _, _, err := g.RandomNeighbor(map[string][]WeightedEdge{}); err != ErrNotEnough {
+		t.Errorf("expected ErrNotEnough, got %v", err)
+	}
+	if _, _, err := g.RandomNeighbor(map[int][]WeightedEdge{}); err != ErrType {
+		t.Errorf("expected ErrType, got %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package discreteprobability
+
+// WithGroupConstraint returns a new Generator in which every group of values has at least
+// its min probability, e.g. at least 20% of the traffic to the new items. groupOf returns
+// the group of a value. A group below its min is scaled up to it within the group, and the
+// other groups are scaled down proportionally to make up for it.
+// The random stream of the new Generator is seeded from g.
+// It will return ErrProbability if any min is negative or the mins sum to more than 1,
+// ErrGroup if a group in min has no values, or ErrNotEnough if the weights of a group
+// or of the groups left to scale down are all zero
+func (g *Generator) WithGroupConstraint(groupOf func(v interface{}) string, min map[string]float64) (*Generator, error) {
+	total := float64(0)
+	for _, p := range min {
+		if !(p >= 0) {
+			return nil, ErrProbability
+		}
+		total += p
+	}
+	if !(total <= 1+weightEpsilon) {
+		return nil, ErrProbability
+	}
+
+	groups := make([]string, g.size)
+	mass := map[string]float64{}
+	for i, value := range g.values {
+		groups[i] = groupOf(value.Interface())
+		mass[groups[i]] += g.probability(i)
+	}
+	for group, p := range min {
+		m, ok := mass[group]
+		if !ok {
+			return nil, ErrGroup
+		}
+		if m <= 0 && p > 0 {
+			return nil, ErrNotEnough
+		}
+	}
+
+	index := make(map[string]int, len(mass))
+	masses, bounds := make([]float64, 0, len(mass)), make([]float64, 0, len(mass))
+	for group, m := range mass {
+		index[group] = len(masses)
+		masses, bounds = append(masses, m), append(bounds, min[group])
+	}
+	raised, scale, err := pin(masses, bounds, false)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make([]float64, g.size)
+	for i, group := range groups {
+		weights[i] = g.probability(i) * scale
+		if raised[index[group]] {
+			weights[i] = g.probability(i) * min[group] / mass[group]
+		}
+	}
+	if weights, err = normalize(weights); err != nil {
+		return nil, err
+	}
+	return g.derive(weights)
+}
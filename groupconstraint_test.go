@@ -0,0 +1,57 @@
+package discreteprobability
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestWithGroupConstraint(t *testing.T) {
+	g, err := New([]string{"new/a", "new/b", "old/c", "old/d"}, []float64{0.05, 0.05, 0.3, 0.6})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+	groupOf := func(v interface{}) string {
+		return strings.Split(v.(string), "/")[0]
+	}
+
+	c, err := g.WithGroupConstraint(groupOf, map[string]float64{"new": 0.4})
+	if err != nil {
+		t.Errorf("WithGroupConstraint error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(c, "new/a", 0.2) || !weightEqual(c, "new/b", 0.2) ||
+		!weightEqual(c, "old/c", 0.2) || !weightEqual(c, "old/d", 0.4) {
+		t.Errorf("unexpected generator %v", c)
+	}
+
+	// a group already above its min is kept
+	c, err = g.WithGroupConstraint(groupOf, map[string]float64{"old": 0.5})
+	if err != nil {
+		t.Errorf("WithGroupConstraint error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(c, "new/a", 0.05) || !weightEqual(c, "old/d", 0.6) {
+		t.Errorf("unexpected generator %v", c)
+	}
+
+	if _, err := g.WithGroupConstraint(groupOf, map[string]float64{"new": 0.6, "old": 0.6}); err != ErrProbability {
+		t.Errorf("expected ErrProbability, got %v", err)
+	}
+	if _, err := g.WithGroupConstraint(groupOf, map[string]float64{"new": math.NaN()}); err != ErrProbability {
+		t.Errorf("expected ErrProbability for NaN, got %v", err)
+	}
+	if _, err := g.WithGroupConstraint(groupOf, map[string]float64{"other": 0.1}); err != ErrGroup {
+		t.Errorf("expected ErrGroup, got %v", err)
+	}
+
+	g, err = New([]string{"new/a", "old/b"}, []float64{0, 1})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+	if _, err := g.WithGroupConstraint(groupOf, map[string]float64{"new": 0.1}); err != ErrNotEnough {
+		t.Errorf("expected ErrNotEnough, got %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package discreteprobability
+
+// Interleave returns a sequence of total values whose counts match the weights exactly, as
+// Allocate, and in which every value is spread as evenly as possible, e.g. for a rotation
+// schedule. The order is the smooth weighted round-robin: every step each value gains its
+// count, and the value with the most is taken and loses total. It's deterministic.
+func (g *Generator) Interleave(total int) []interface{} {
+	if total <= 0 {
+		return nil
+	}
+	values, weights := g.Distribution()
+	counts := allocate(weights, total)
+
+	sequence := make([]interface{}, total)
+	current := make([]int, len(values))
+	for n := range sequence {
+		best := -1
+		for i, count := range counts {
+			current[i] += count
+			if count > 0 && (best < 0 || current[i] > current[best]) {
+				best = i
+			}
+		}
+		current[best] -= total
+		sequence[n] = values[best]
+	}
+	return sequence
+}
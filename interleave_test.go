@@ -0,0 +1,35 @@
+package discreteprobability
+
+import (
+	"testing"
+)
+
+func TestInterleave(t *testing.T) {
+	g, _ := New([]string{"a", "b", "c"}, []float64{0.2, 0.3, 0.5})
+	sequence := g.Interleave(10)
+	counts := map[interface{}]int{}
+	for _, v := range sequence {
+		counts[v]++
+	}
+	if len(sequence) != 10 || counts["a"] != 2 || counts["b"] != 3 || counts["c"] != 5 {
+		t.Errorf("unexpected sequence %v", sequence)
+	}
+
+	// the gaps between the occurrences of a value are at most twice the even spacing
+	for v, count := range counts {
+		last := -1
+		for i := 0; i < 2*len(sequence); i++ {
+			if sequence[i%len(sequence)] != v {
+				continue
+			}
+			if last >= 0 && i-last > 2*len(sequence)/count {
+				t.Errorf("%v has a gap of %v in %v", v, i-last, sequence)
+			}
+			last = i
+		}
+	}
+
+	if s := g.Interleave(0); s != nil {
+		t.Errorf("expected no values, got %v", s)
+	}
+}
@@ -0,0 +1,6 @@
+package discreteprobability
+
+// fitsInt reports whether v fits in an int of intSize bits.
+func fitsInt(v int64) bool {
+	return v >= -1<<(intSize-1) && v <= 1<<(intSize-1)-1
+}
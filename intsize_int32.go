@@ -0,0 +1,6 @@
+//go:build dp_int32
+
+package discreteprobability
+
+// intSize simulates a 32-bit int on a 64-bit platform.
+const intSize = 32
@@ -0,0 +1,9 @@
+//go:build !dp_int32
+
+package discreteprobability
+
+import "math/bits"
+
+// intSize is the size of an int in bits. Build with the dp_int32 tag to simulate a
+// 32-bit platform, e.g. go test -tags dp_int32.
+const intSize = bits.UintSize
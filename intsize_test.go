@@ -0,0 +1,44 @@
+package discreteprobability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRandomIntSafeOverflow(t *testing.T) {
+	g, err := New([]int64{math.MaxInt32 + 1}, []float64{1})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+	if v := g.RandomInt64(); v != math.MaxInt32+1 {
+		t.Errorf("expected %v, got %v", int64(math.MaxInt32+1), v)
+	}
+
+	v, err := g.RandomIntSafe()
+	if intSize == 32 {
+		if err != ErrOverflow {
+			t.Errorf("expected ErrOverflow on 32-bit, got %v %v", v, err)
+		}
+	} else if err != nil || int64(v) != math.MaxInt32+1 {
+		t.Errorf("expected %v, got %v %v", int64(math.MaxInt32+1), v, err)
+	}
+
+	g, _ = New([]int64{math.MinInt64}, []float64{1})
+	if _, err := g.RandomIntSafe(); intSize == 32 && err != ErrOverflow || intSize == 64 && err != nil {
+		t.Errorf("unexpected error %v for %v-bit int", err, intSize)
+	}
+
+	g, _ = New([]int8{-5}, []float64{1})
+	if v, err := g.RandomIntSafe(); err != nil || v != -5 {
+		t.Errorf("expected -5, got %v %v", v, err)
+	}
+	if v := g.RandomInt64(); v != -5 {
+		t.Errorf("expected -5, got %v", v)
+	}
+
+	g, _ = New([]uint{5}, []float64{1})
+	if _, err := g.RandomIntSafe(); err != ErrType {
+		t.Errorf("expected ErrType, got %v", err)
+	}
+}
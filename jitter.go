@@ -0,0 +1,52 @@
+package discreteprobability
+
+import (
+	"net/http"
+	"time"
+)
+
+// Jitter draws retry delays from a weighted menu of durations.
+type Jitter struct {
+	g *Generator
+}
+
+// NewJitter returns a new Jitter. It will return any error of New.
+func NewJitter(durations []time.Duration, weights []float64) (*Jitter, error) {
+	g, err := New(durations, weights)
+	if err != nil {
+		return nil, err
+	}
+	return &Jitter{g: g}, nil
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (j *Jitter) SetSeed(s int64) {
+	j.g.SetSeed(s)
+}
+
+// Next returns the next delay.
+func (j *Jitter) Next() time.Duration {
+	return time.Duration(j.g.random().Int())
+}
+
+// NextBackOff returns the next delay, which with Reset implements
+// the BackOff interface of github.com/cenkalti/backoff.
+func (j *Jitter) NextBackOff() time.Duration {
+	return j.Next()
+}
+
+// Reset does nothing since the delays don't depend on the previous attempts.
+func (j *Jitter) Reset() {}
+
+// Backoff returns the next delay clamped to [min, max]. It matches the Backoff function
+// of github.com/hashicorp/go-retryablehttp, e.g. client.Backoff = jitter.Backoff
+func (j *Jitter) Backoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	d := j.Next()
+	if d < min {
+		return min
+	}
+	if max > 0 && d > max {
+		return max
+	}
+	return d
+}
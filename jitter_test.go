@@ -0,0 +1,30 @@
+package discreteprobability
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJitter(t *testing.T) {
+	j, err := NewJitter([]time.Duration{time.Second, 2 * time.Second}, []float64{0.5, 0.5})
+	if err != nil {
+		t.Errorf("NewJitter error %v", err)
+		t.FailNow()
+	}
+	j.SetSeed(time.Now().Unix())
+
+	for i := 0; i < repeats/100; i++ {
+		if d := j.NextBackOff(); d != time.Second && d != 2*time.Second {
+			t.Errorf("unexpected delay %v", d)
+			t.FailNow()
+		}
+		if d := j.Backoff(1500*time.Millisecond, 1800*time.Millisecond, i, nil); d != 1500*time.Millisecond && d != 1800*time.Millisecond {
+			t.Errorf("unexpected clamped delay %v", d)
+			t.FailNow()
+		}
+	}
+
+	if _, err := NewJitter([]time.Duration{time.Second}, nil); err != ErrLength {
+		t.Errorf("expected ErrLength, got %v", err)
+	}
+}
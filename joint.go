@@ -0,0 +1,155 @@
+package discreteprobability
+
+import "reflect"
+
+// Joint is the joint distribution of two attributes A and B,
+// so that correlated pairs can be sampled consistently.
+type Joint struct {
+	valuesA []reflect.Value
+	valuesB []reflect.Value
+	weights [][]float64
+	cells   *Generator
+}
+
+// NewJoint returns a new Joint. The jointWeights[i][j] is the probability of
+// (valuesA[i], valuesB[j]). It will return error if the shape of jointWeights
+// doesn't match the values or the sum of weights not equal to 1
+func NewJoint(a interface{}, b interface{}, jointWeights [][]float64) (*Joint, error) {
+	valuesA, err := sliceValues(a)
+	if err != nil {
+		return nil, err
+	}
+	valuesB, err := sliceValues(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(jointWeights) != len(valuesA) {
+		return nil, ErrLength
+	}
+
+	size := len(valuesA) * len(valuesB)
+	cells := make([]int, 0, size)
+	weights := make([]float64, 0, size)
+	copied := make([][]float64, len(jointWeights))
+	for i, row := range jointWeights {
+		if len(row) != len(valuesB) {
+			return nil, ErrLength
+		}
+		copied[i] = append([]float64(nil), row...)
+		for j, weight := range row {
+			cells = append(cells, i*len(valuesB)+j)
+			weights = append(weights, weight)
+		}
+	}
+
+	g, err := New(cells, weights)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Joint{
+		valuesA: valuesA,
+		valuesB: valuesB,
+		weights: copied,
+		cells:   g,
+	}, nil
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (j *Joint) SetSeed(s int64) {
+	j.cells.SetSeed(s)
+}
+
+// Random returns a pair of values drawn with the joint weights.
+func (j *Joint) Random() (interface{}, interface{}) {
+	cell := j.cells.RandomInt()
+	n := len(j.valuesB)
+	return j.valuesA[cell/n].Interface(), j.valuesB[cell%n].Interface()
+}
+
+// MarginalA returns a Generator of A with the weights summed over B.
+func (j *Joint) MarginalA() (*Generator, error) {
+	weights := make([]float64, len(j.valuesA))
+	for i, row := range j.weights {
+		for _, weight := range row {
+			weights[i] += weight
+		}
+	}
+	return newGenerator(copyValues(j.valuesA), weights)
+}
+
+// MarginalB returns a Generator of B with the weights summed over A.
+func (j *Joint) MarginalB() (*Generator, error) {
+	weights := make([]float64, len(j.valuesB))
+	for _, row := range j.weights {
+		for i, weight := range row {
+			weights[i] += weight
+		}
+	}
+	return newGenerator(copyValues(j.valuesB), weights)
+}
+
+// GivenA returns a Generator of B conditioned on the value a of A.
+// It will return ErrCondition if a is not one of the values of A
+func (j *Joint) GivenA(a interface{}) (*Generator, error) {
+	i := indexOf(j.valuesA, a)
+	if i < 0 {
+		return nil, ErrCondition
+	}
+	weights, err := normalize(j.weights[i])
+	if err != nil {
+		return nil, err
+	}
+	return newGenerator(copyValues(j.valuesB), weights)
+}
+
+// GivenB returns a Generator of A conditioned on the value b of B.
+// It will return ErrCondition if b is not one of the values of B
+func (j *Joint) GivenB(b interface{}) (*Generator, error) {
+	k := indexOf(j.valuesB, b)
+	if k < 0 {
+		return nil, ErrCondition
+	}
+	column := make([]float64, len(j.valuesA))
+	for i, row := range j.weights {
+		column[i] = row[k]
+	}
+	weights, err := normalize(column)
+	if err != nil {
+		return nil, err
+	}
+	return newGenerator(copyValues(j.valuesA), weights)
+}
+
+// copyValues returns a copy of values, so a new Generator can sort it freely.
+func copyValues(values []reflect.Value) []reflect.Value {
+	return append([]reflect.Value(nil), values...)
+}
+
+// indexOf returns the index of v in values, or -1 if not found.
+func indexOf(values []reflect.Value, v interface{}) int {
+	for i, value := range values {
+		if reflect.DeepEqual(value.Interface(), v) {
+			return i
+		}
+	}
+	return -1
+}
+
+// normalize returns a copy of w scaled to sum to 1.
+// It will return ErrWeightSum if the sum of w is not positive
+func normalize(w []float64) ([]float64, error) {
+	sum := float64(0)
+	for _, weight := range w {
+		sum += weight
+	}
+	if sum <= 0 {
+		return nil, ErrWeightSum
+	}
+
+	normalized := make([]float64, len(w))
+	for i, weight := range w {
+		normalized[i] = weight / sum
+	}
+	return normalized, nil
+}
@@ -0,0 +1,99 @@
+package discreteprobability
+
+import (
+	"testing"
+	"time"
+)
+
+func generateJoint(t *testing.T) *Joint {
+	j, err := NewJoint([]string{"mobile", "desktop"}, []string{"us", "eu"}, [][]float64{
+		{0.4, 0.1},
+		{0.2, 0.3},
+	})
+	if err != nil {
+		t.Errorf("NewJoint error %v", err)
+		t.FailNow()
+	}
+	j.SetSeed(time.Now().Unix())
+	return j
+}
+
+func TestJointDistribution(t *testing.T) {
+	j := generateJoint(t)
+	occurrence := map[[2]string]float64{}
+	for i := 0; i < repeats; i++ {
+		a, b := j.Random()
+		occurrence[[2]string{a.(string), b.(string)}]++
+	}
+
+	expected := map[[2]string]float64{
+		{"mobile", "us"}:  0.4,
+		{"mobile", "eu"}:  0.1,
+		{"desktop", "us"}: 0.2,
+		{"desktop", "eu"}: 0.3,
+	}
+	for pair, weight := range expected {
+		p := weight * repeats
+		d := p * 5 / 100
+		if v := occurrence[pair]; v > p+d || v < p-d {
+			t.Errorf("incorrect distribution of %v, expected %f, got %f", pair, p, v)
+		}
+	}
+}
+
+func TestJointMarginal(t *testing.T) {
+	j := generateJoint(t)
+	m, err := j.MarginalA()
+	if err != nil {
+		t.Errorf("MarginalA error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(m, "mobile", 0.5) || !weightEqual(m, "desktop", 0.5) {
+		t.Errorf("incorrect marginal of A %v %v", m.values, m.weights)
+	}
+
+	m, err = j.MarginalB()
+	if err != nil {
+		t.Errorf("MarginalB error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(m, "us", 0.6) || !weightEqual(m, "eu", 0.4) {
+		t.Errorf("incorrect marginal of B %v %v", m.values, m.weights)
+	}
+}
+
+func TestJointConditional(t *testing.T) {
+	j := generateJoint(t)
+	c, err := j.GivenA("mobile")
+	if err != nil {
+		t.Errorf("GivenA error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(c, "us", 0.8) || !weightEqual(c, "eu", 0.2) {
+		t.Errorf("incorrect conditional %v %v", c.values, c.weights)
+	}
+
+	c, err = j.GivenB("eu")
+	if err != nil {
+		t.Errorf("GivenB error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(c, "desktop", 0.75) || !weightEqual(c, "mobile", 0.25) {
+		t.Errorf("incorrect conditional %v %v", c.values, c.weights)
+	}
+
+	if _, err := j.GivenA("tablet"); err != ErrCondition {
+		t.Errorf("expected ErrCondition, got %v", err)
+	}
+}
+
+func TestJointShape(t *testing.T) {
+	_, err := NewJoint([]int{1, 2}, []int{1, 2}, [][]float64{{0.5, 0.5}})
+	if err != ErrLength {
+		t.Errorf("expected ErrLength, got %v", err)
+	}
+	_, err = NewJoint([]int{1, 2}, []int{1, 2}, [][]float64{{0.5, 0.25}, {0.25}})
+	if err != ErrLength {
+		t.Errorf("expected ErrLength, got %v", err)
+	}
+}
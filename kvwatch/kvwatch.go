@@ -0,0 +1,142 @@
+// Package kvwatch keeps a generator in sync with a spec stored in a key-value store, such as
+// etcd or Consul, for the weighted rollouts driven by the service discovery store.
+// The package doesn't depend on the clients, a store is used through the Client interface.
+// Example usage:
+//
+//		w, err := kvwatch.WatchConfig(ctx, client, "rollout/checkout")
+//		if err != nil {
+//			panic(err) // Error handlers
+//		}
+//		variant := w.RandomString()
+//
+//		With the value "v2:0.1,v1:0.9" of the key, 10% of the draws are v2. When the value
+//		changes, the draws after the change use the new weights.
+package kvwatch
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+// ErrClosed is returned when the watch ends before the first value of the key
+var ErrClosed = errors.New("watch closed")
+
+// Client watches a key of a key-value store. Watch sends the current value of the key and then
+// every new value, until ctx is done and the channel is closed. With etcd it's a Get followed
+// by a Watch from the next revision; with Consul it's a loop of blocking queries on the key.
+type Client interface {
+	Watch(ctx context.Context, key string) (<-chan []byte, error)
+}
+
+// Watcher draws values with the latest valid spec of the key. It's safe for concurrent use.
+type Watcher struct {
+	mu  sync.Mutex
+	g   *discreteprobability.Generator
+	rnd *rand.Rand
+	err error
+	// values is the type of the values of the first spec, []int or []string
+	values interface{}
+}
+
+// WatchConfig waits for the first value of key, a spec of Spec.UnmarshalText, and returns a
+// Watcher which swaps its generator whenever the value changes, until ctx is done.
+// It will return the error of the client or of the first spec, or ErrClosed if the watch ends first.
+// An invalid later spec is returned by Err, and the old generator is kept. The values of the later
+// specs have the type of the first one, see set.
+func WatchConfig(ctx context.Context, client Client, key string) (*Watcher, error) {
+	updates, err := client.Watch(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	w := &Watcher{rnd: rand.New(rand.NewSource(time.Now().UnixNano()))}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case value, ok := <-updates:
+		if !ok {
+			return nil, ErrClosed
+		}
+		if err := w.set(value); err != nil {
+			return nil, err
+		}
+	}
+
+	go func() {
+		for value := range updates {
+			w.set(value)
+		}
+	}()
+	return w, nil
+}
+
+// set parses the spec and swaps the generator. The values keep the type of the first spec,
+// so a spec of strings which are all integers stays strings, and a spec with other than
+// integers is an error if the first one was integers.
+func (w *Watcher) set(value []byte) error {
+	w.mu.Lock()
+	spec := discreteprobability.Spec{Values: w.values}
+	w.mu.Unlock()
+	err := spec.UnmarshalText(value)
+	var g *discreteprobability.Generator
+	if err == nil {
+		g, err = spec.Generator()
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.err = err
+	if err != nil {
+		return err
+	}
+	g.SetSeed(w.rnd.Int63())
+	w.g = g
+	if w.values == nil {
+		w.values = spec.Values
+	}
+	return nil
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (w *Watcher) SetSeed(s int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.rnd = rand.New(rand.NewSource(s))
+	w.g.SetSeed(w.rnd.Int63())
+}
+
+// Err returns the error of the latest spec, or nil if it's valid.
+func (w *Watcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+// RandomString returns the value with the corresponding weights of the latest valid spec.
+// The values of the spec should not be all integers.
+func (w *Watcher) RandomString() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.g.RandomString()
+}
+
+// RandomInt returns the value with the corresponding weights of the latest valid spec.
+// Will panic if the values of the spec are not all integers.
+func (w *Watcher) RandomInt() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.g.RandomInt()
+}
+
+// RandomInterface returns the value with the corresponding weights of the latest valid spec,
+// which is an int if all the values of the spec are integers and a string if not.
+func (w *Watcher) RandomInterface() interface{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.g.RandomInterface()
+}
@@ -0,0 +1,120 @@
+package kvwatch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+type fakeClient struct {
+	updates chan []byte
+	err     error
+}
+
+func (c fakeClient) Watch(ctx context.Context, key string) (<-chan []byte, error) {
+	return c.updates, c.err
+}
+
+// eventually waits for the draws of w to be v.
+func eventually(w *Watcher, v string) bool {
+	for i := 0; i < 1000; i++ {
+		if w.RandomString() == v {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return false
+}
+
+func TestWatchConfig(t *testing.T) {
+	updates := make(chan []byte, 1)
+	updates <- []byte("a:1,b:0")
+	w, err := WatchConfig(context.Background(), fakeClient{updates: updates}, "rollout")
+	if err != nil {
+		t.Errorf("WatchConfig error %v", err)
+		t.FailNow()
+	}
+	w.SetSeed(1)
+	if v := w.RandomString(); v != "a" {
+		t.Errorf("expected a, got %v", v)
+	}
+
+	updates <- []byte("a:0,b:1")
+	if !eventually(w, "b") {
+		t.Errorf("generator not swapped")
+		t.FailNow()
+	}
+
+	// an invalid spec keeps the old generator
+	updates <- []byte("a:0.5,b:0.6")
+	for i := 0; i < 1000 && w.Err() == nil; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	if w.Err() != discreteprobability.ErrWeightSum {
+		t.Errorf("expected ErrWeightSum, got %v", w.Err())
+	}
+	if v := w.RandomString(); v != "b" {
+		t.Errorf("expected the old generator, got %v", v)
+	}
+	close(updates)
+}
+
+func TestWatchConfigInvalid(t *testing.T) {
+	updates := make(chan []byte, 1)
+	updates <- []byte("a:0.5,b:0.6")
+	if _, err := WatchConfig(context.Background(), fakeClient{updates: updates}, "k"); err != discreteprobability.ErrWeightSum {
+		t.Errorf("expected ErrWeightSum, got %v", err)
+	}
+
+	closed := make(chan []byte)
+	close(closed)
+	if _, err := WatchConfig(context.Background(), fakeClient{updates: closed}, "k"); err != ErrClosed {
+		t.Errorf("expected ErrClosed, got %v", err)
+	}
+
+	fail := errors.New("unavailable")
+	if _, err := WatchConfig(context.Background(), fakeClient{err: fail}, "k"); err != fail {
+		t.Errorf("expected the client error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := WatchConfig(ctx, fakeClient{updates: make(chan []byte)}, "k"); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestWatchConfigPinnedType(t *testing.T) {
+	updates := make(chan []byte, 1)
+	updates <- []byte("a:1,b:0")
+	w, err := WatchConfig(context.Background(), fakeClient{updates: updates}, "rollout")
+	if err != nil {
+		t.Errorf("WatchConfig error %v", err)
+		t.FailNow()
+	}
+
+	// integers of a string spec stay strings
+	updates <- []byte("1:0,2:1")
+	if !eventually(w, "2") {
+		t.Errorf("values not kept as strings")
+	}
+
+	ints := make(chan []byte, 1)
+	ints <- []byte("1:1,2:0")
+	w, _ = WatchConfig(context.Background(), fakeClient{updates: ints}, "rollout")
+	ints <- []byte("a:0,b:1")
+	for i := 0; i < 1000 && w.Err() == nil; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	if w.Err() != discreteprobability.ErrSpec {
+		t.Errorf("expected ErrSpec for strings after integers, got %v", w.Err())
+	}
+	if v := w.RandomInt(); v != 1 {
+		t.Errorf("expected the old generator, got %v", v)
+	}
+	close(ints)
+	close(updates)
+}
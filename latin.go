@@ -0,0 +1,35 @@
+package discreteprobability
+
+import "math/rand"
+
+// SampleLatin returns n values drawn with Latin hypercube sampling: [0, 1) is split into
+// n bins of equal width, one uniform is drawn within each bin and mapped through the
+// cumulative weights. Each value is drawn less than 2 off its expected count, so the estimates
+// over the sample have a much lower variance than with n independent draws. The values are
+// returned in a random order.
+func (g *Generator) SampleLatin(n int) []interface{} {
+	if n <= 0 {
+		return nil
+	}
+
+	if g.tracer != nil {
+		defer g.traceBatch(n)
+	}
+
+	r := rand.New(g.source)
+	samples := make([]interface{}, n)
+	i := 0
+	for bin := range samples {
+		u := (float64(bin) + uniform(g.source)) / float64(n)
+		for i < g.size-1 && g.weights[i] < u {
+			i++
+		}
+		samples[bin] = g.values[i].Interface()
+		g.observe(i, true)
+	}
+
+	r.Shuffle(n, func(i, j int) {
+		samples[i], samples[j] = samples[j], samples[i]
+	})
+	return samples
+}
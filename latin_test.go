@@ -0,0 +1,28 @@
+package discreteprobability
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSampleLatin(t *testing.T) {
+	g, _ := New([]string{"a", "b", "c"}, []float64{0.17, 0.33, 0.5})
+	g.SetSeed(time.Now().Unix())
+	for round := 0; round < 100; round++ {
+		counts := map[interface{}]float64{}
+		for _, v := range g.SampleLatin(20) {
+			counts[v]++
+		}
+		for v, w := range map[string]float64{"a": 0.17, "b": 0.33, "c": 0.5} {
+			if expected := w * 20; math.Abs(counts[v]-expected) >= 2 {
+				t.Errorf("value %v drawn %v times, expected %v", v, counts[v], expected)
+				t.FailNow()
+			}
+		}
+	}
+
+	if s := g.SampleLatin(0); s != nil {
+		t.Errorf("expected no values, got %v", s)
+	}
+}
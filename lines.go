@@ -0,0 +1,84 @@
+package discreteprobability
+
+import (
+	"bufio"
+	"container/heap"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+// reservoirItem is a line kept in the reservoir with its key u^(1/w).
+type reservoirItem struct {
+	line  string
+	index int
+	key   float64
+}
+
+// reservoir is a min-heap of the items ordered by key.
+type reservoir []reservoirItem
+
+func (r reservoir) Len() int            { return len(r) }
+func (r reservoir) Less(i, j int) bool  { return r[i].key < r[j].key }
+func (r reservoir) Swap(i, j int)       { r[i], r[j] = r[j], r[i] }
+func (r *reservoir) Push(x interface{}) { *r = append(*r, x.(reservoirItem)) }
+func (r *reservoir) Pop() interface{} {
+	old := *r
+	item := old[len(old)-1]
+	*r = old[:len(old)-1]
+	return item
+}
+
+// SampleLines picks k lines from r without replacement in a single pass, where the
+// weight of each line is given by weightFn. Lines with a weight not greater than 0
+// are never picked. The lines are returned in the order they appear in r,
+// and fewer than k lines are returned if r doesn't have enough of them.
+func SampleLines(r io.Reader, weightFn func(line string) float64, k int) ([]string, error) {
+	return SampleLinesFrom(rand.NewSource(time.Now().UnixNano()), r, weightFn, k)
+}
+
+// SampleLinesFrom is as SampleLines, but the lines are picked with the randomness of source,
+// which is owned by the caller.
+func SampleLinesFrom(source rand.Source, r io.Reader, weightFn func(line string) float64, k int) ([]string, error) {
+	if k <= 0 {
+		return nil, nil
+	}
+
+	reader := bufio.NewReader(r)
+	items := make(reservoir, 0, k)
+	for index := 0; ; index++ {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if err == io.EOF && line == "" {
+			break
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if w := weightFn(line); w > 0 {
+			// Efraimidis-Spirakis: keep the k largest keys of u^(1/w)
+			key := math.Pow(uniform(source), 1/w)
+			if len(items) < k {
+				heap.Push(&items, reservoirItem{line: line, index: index, key: key})
+			} else if key > items[0].key {
+				items[0] = reservoirItem{line: line, index: index, key: key}
+				heap.Fix(&items, 0)
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].index < items[j].index })
+	lines := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = item.line
+	}
+	return lines, nil
+}
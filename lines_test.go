@@ -0,0 +1,72 @@
+package discreteprobability
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSampleLines(t *testing.T) {
+	text := "INFO a\nERROR b\nINFO c\nERROR d\nDEBUG e"
+	weightFn := func(line string) float64 {
+		switch {
+		case strings.HasPrefix(line, "ERROR"):
+			return 100
+		case strings.HasPrefix(line, "INFO"):
+			return 1
+		}
+		return 0
+	}
+
+	occurrence := map[string]int{}
+	for i := 0; i < repeats/100; i++ {
+		lines, err := SampleLines(strings.NewReader(text), weightFn, 2)
+		if err != nil {
+			t.Errorf("SampleLines error %v", err)
+			t.FailNow()
+		}
+		if len(lines) != 2 {
+			t.Errorf("expected 2 lines, got %v", lines)
+			t.FailNow()
+		}
+		if strings.Index(text, lines[0]) > strings.Index(text, lines[1]) {
+			t.Errorf("lines are not in order %v", lines)
+		}
+		for _, line := range lines {
+			occurrence[line]++
+		}
+	}
+
+	if occurrence["DEBUG e"] != 0 {
+		t.Errorf("line with zero weight is picked")
+	}
+	if occurrence["ERROR b"] < occurrence["INFO a"]*10 {
+		t.Errorf("heavy lines are not preferred %v", occurrence)
+	}
+}
+
+func TestSampleLinesShortInput(t *testing.T) {
+	lines, err := SampleLines(strings.NewReader("a\nb\n"), func(string) float64 { return 1 }, 5)
+	if err != nil {
+		t.Errorf("SampleLines error %v", err)
+		t.FailNow()
+	}
+	if len(lines) != 2 || lines[0] != "a" || lines[1] != "b" {
+		t.Errorf("expected all the lines, got %v", lines)
+	}
+}
+
+func TestSampleLinesFrom(t *testing.T) {
+	text := "a\nb\nc\nd\ne\nf\ng\nh"
+	weightFn := func(string) float64 { return 1 }
+	lines, err := SampleLinesFrom(rand.NewSource(42), strings.NewReader(text), weightFn, 3)
+	if err != nil {
+		t.Errorf("SampleLinesFrom error %v", err)
+		t.FailNow()
+	}
+	replay, _ := SampleLinesFrom(rand.NewSource(42), strings.NewReader(text), weightFn, 3)
+	if !reflect.DeepEqual(lines, replay) {
+		t.Errorf("expected the same lines with the same seed, got %v and %v", lines, replay)
+	}
+}
@@ -0,0 +1,117 @@
+// Package loadgen is a building block of load generators: a workload draws the next operation
+// of a virtual user with the weights of the mix of operations, and the think time before it
+// from a second generator.
+// Example usage:
+//
+//		w, err := loadgen.NewWorkload(map[string]float64{"browse": 70, "search": 20, "checkout": 10})
+//		if err != nil {
+//			panic(err) // Error handlers
+//		}
+//		think, _ := discreteprobability.New([]time.Duration{time.Second, 5 * time.Second}, []float64{0.8, 0.2})
+//		w.WithThinkTime(think)
+//		for i := 0; i < users; i++ {
+//			go w.Run(ctx, func(ctx context.Context, op string) { client.Do(ctx, op) })
+//		}
+package loadgen
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+// Workload draws the operations and think times. It's safe for concurrent use, so one Workload
+// drives all the virtual users.
+type Workload struct {
+	mu    sync.Mutex
+	ops   *discreteprobability.Generator
+	think *discreteprobability.Generator
+	rnd   *rand.Rand
+}
+
+// NewWorkload returns a new Workload of the operations with their relative weights, e.g. percentages.
+// It will return the error of discreteprobability.NewNormalized if the weights are invalid
+func NewWorkload(ops map[string]float64) (*Workload, error) {
+	names := make([]string, 0, len(ops))
+	for name := range ops {
+		names = append(names, name)
+	}
+	// map iteration order is random, sort the operations so a seeded workload is reproducible
+	sort.Strings(names)
+	weights := make([]float64, len(names))
+	for i, name := range names {
+		weights[i] = ops[name]
+	}
+
+	g, err := discreteprobability.NewNormalized(names, weights)
+	if err != nil {
+		return nil, err
+	}
+	w := &Workload{ops: g, rnd: rand.New(rand.NewSource(time.Now().UnixNano()))}
+	g.SetSeed(w.rnd.Int63())
+	return w, nil
+}
+
+// WithThinkTime sets the generator of the think times, whose values are time.Duration, and returns w.
+// Without it there is no think time.
+func (w *Workload) WithThinkTime(think *discreteprobability.Generator) *Workload {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.think = think
+	if think != nil {
+		think.SetSeed(w.rnd.Int63())
+	}
+	return w
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (w *Workload) SetSeed(s int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.rnd = rand.New(rand.NewSource(s))
+	w.ops.SetSeed(w.rnd.Int63())
+	if w.think != nil {
+		w.think.SetSeed(w.rnd.Int63())
+	}
+}
+
+// NextOp returns the next operation.
+func (w *Workload) NextOp() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.ops.RandomString()
+}
+
+// ThinkTime returns the time to wait before the next operation, or 0 without a think time generator.
+func (w *Workload) ThinkTime() time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.think == nil {
+		return 0
+	}
+	return time.Duration(w.think.RandomInt64())
+}
+
+// Run is the loop of a virtual user: it waits for a think time and does the next operation,
+// until ctx is done.
+func (w *Workload) Run(ctx context.Context, do func(ctx context.Context, op string)) {
+	for {
+		if think := w.ThinkTime(); think > 0 {
+			timer := time.NewTimer(think)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		do(ctx, w.NextOp())
+	}
+}
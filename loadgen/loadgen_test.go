@@ -0,0 +1,75 @@
+package loadgen
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+func TestWorkload(t *testing.T) {
+	w, err := NewWorkload(map[string]float64{"browse": 70, "search": 20, "checkout": 10})
+	if err != nil {
+		t.Errorf("NewWorkload error %v", err)
+		t.FailNow()
+	}
+	w.SetSeed(1)
+	if w.ThinkTime() != 0 {
+		t.Errorf("expected no think time")
+	}
+
+	count := map[string]float64{}
+	for i := 0; i < 100000; i++ {
+		count[w.NextOp()]++
+	}
+	for op, weight := range map[string]float64{"browse": 0.7, "search": 0.2, "checkout": 0.1} {
+		p := weight * 100000
+		if d := p * 3 / 100; count[op] > p+d || count[op] < p-d {
+			t.Errorf("incorrect mix of %v, expected %f, got %f", op, p, count[op])
+		}
+	}
+
+	think, _ := discreteprobability.New([]time.Duration{time.Second, 2 * time.Second}, []float64{0, 1})
+	if v := w.WithThinkTime(think).ThinkTime(); v != 2*time.Second {
+		t.Errorf("expected 2s think time, got %v", v)
+	}
+}
+
+func TestRun(t *testing.T) {
+	w, _ := NewWorkload(map[string]float64{"ping": 1})
+	think, _ := discreteprobability.New([]time.Duration{time.Millisecond}, []float64{1})
+	w.WithThinkTime(think)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var mu sync.Mutex
+	ops := 0
+	done := make(chan struct{})
+	go func() {
+		w.Run(ctx, func(ctx context.Context, op string) {
+			mu.Lock()
+			defer mu.Unlock()
+			if ops++; ops == 5 {
+				cancel()
+			}
+		})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Errorf("Run did not stop")
+		t.FailNow()
+	}
+	if ops != 5 {
+		t.Errorf("expected 5 operations, got %v", ops)
+	}
+}
+
+func TestNewWorkloadErrors(t *testing.T) {
+	if _, err := NewWorkload(map[string]float64{"a": -1, "b": 2}); err != discreteprobability.ErrNegativeWeight {
+		t.Errorf("expected ErrNegativeWeight, got %v", err)
+	}
+}
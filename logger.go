@@ -0,0 +1,67 @@
+package discreteprobability
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync/atomic"
+)
+
+// drawLogger is the logger of the draws. draws counts the draws, and a draw is logged
+// whenever the count times the rate reaches the next integer. The count is atomic, as
+// the draws of Freeze and Sharded are concurrent.
+type drawLogger struct {
+	logger *slog.Logger
+	rate   float64
+	draws  atomic.Uint64
+}
+
+// WithLogger sets the logger of g and returns g. The values with their probabilities and the
+// warnings of Validate are logged at once, at the debug level. A fraction drawRate of the draws,
+// see WithOnDraw, is logged at the debug level too, e.g. 0.01 logs every 100th draw,
+// and 0 logs none. Which draws are logged doesn't depend on the random stream.
+// A copy of Clone counts its draws apart from g.
+func (g *Generator) WithLogger(logger *slog.Logger, drawRate float64) *Generator {
+	if logger == nil {
+		g.logger = nil
+		return g
+	}
+	g.logger = &drawLogger{logger: logger, rate: drawRate}
+	if !logger.Enabled(context.Background(), slog.LevelDebug) {
+		return g
+	}
+
+	attrs := make([]slog.Attr, g.size)
+	for i, value := range g.values {
+		attrs[i] = slog.Float64(fmt.Sprint(value.Interface()), g.probability(i))
+	}
+	logger.LogAttrs(context.Background(), slog.LevelDebug, "discreteprobability generator",
+		slog.Int("size", g.size),
+		slog.String("algorithm", g.Algorithm()),
+		slog.Any("weights", slog.GroupValue(attrs...)),
+	)
+	for _, w := range g.Validate() {
+		logger.LogAttrs(context.Background(), slog.LevelDebug, "discreteprobability warning",
+			slog.Any("value", w.Value),
+			slog.String("message", w.Message),
+		)
+	}
+	return g
+}
+
+func (g *Generator) logDraw(i int) {
+	l := g.logger
+	if l.rate <= 0 {
+		return
+	}
+	n := float64(l.draws.Add(1))
+	// a rate like 0.1 doesn't multiply to exact integers
+	if math.Floor(n*l.rate+weightEpsilon) == math.Floor((n-1)*l.rate+weightEpsilon) {
+		return
+	}
+	l.logger.LogAttrs(context.Background(), slog.LevelDebug, "discreteprobability draw",
+		slog.Any("value", g.values[i].Interface()),
+		slog.Float64("probability", g.probability(i)),
+	)
+}
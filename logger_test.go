@@ -0,0 +1,71 @@
+package discreteprobability
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestWithLogger(t *testing.T) {
+	g, err := New([]string{"a", "b", "c"}, []float64{0, 0.25, 0.75})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+
+	var b bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&b, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	g.WithLogger(logger, 0.1)
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "weights.b=0.25") || !strings.Contains(lines[1], "value=a") {
+		t.Errorf("unexpected construction logs %v", lines)
+		t.FailNow()
+	}
+
+	b.Reset()
+	for i := 0; i < 100; i++ {
+		g.RandomString()
+	}
+	if n := strings.Count(b.String(), "discreteprobability draw"); n != 10 {
+		t.Errorf("expected 10 logged draws, got %v", n)
+	}
+
+	b.Reset()
+	g.WithLogger(slog.New(slog.NewTextHandler(&b, nil)), 1)
+	g.RandomString()
+	if b.Len() != 0 {
+		t.Errorf("expected no logs above the debug level, got %v", b.String())
+	}
+}
+
+func TestWithLoggerEveryDraw(t *testing.T) {
+	g, _ := New([]int{1, 2}, []float64{0.5, 0.5})
+	var b bytes.Buffer
+	g.WithLogger(slog.New(slog.NewTextHandler(&b, &slog.HandlerOptions{Level: slog.LevelDebug})), 1)
+
+	b.Reset()
+	g.SampleN(100)
+	g.RandomSeeded(1)
+	g.Controller().Random()
+	c := g.Clone()
+	c.RandomInt()
+	if n := strings.Count(b.String(), "discreteprobability draw"); n != 103 {
+		t.Errorf("expected 103 logged draws, got %v", n)
+	}
+
+	// the copies of Freeze draw concurrently, which is safe for the logger too
+	f := g.Freeze()
+	done := make(chan struct{})
+	for w := 0; w < 4; w++ {
+		go func() {
+			for i := 0; i < 100; i++ {
+				f.Random()
+			}
+			done <- struct{}{}
+		}()
+	}
+	for w := 0; w < 4; w++ {
+		<-done
+	}
+}
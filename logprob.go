@@ -0,0 +1,23 @@
+package discreteprobability
+
+import (
+	"math"
+	"reflect"
+)
+
+// LogProb returns the natural logarithm of the probability of value,
+// -Inf if its weight is zero. It will return ErrValue if value is not one of the values.
+func (g *Generator) LogProb(value interface{}) (float64, error) {
+	p := float64(0)
+	found := false
+	for i, v := range g.values {
+		if reflect.DeepEqual(v.Interface(), value) {
+			p += g.probability(i)
+			found = true
+		}
+	}
+	if !found {
+		return 0, ErrValue
+	}
+	return math.Log(p), nil
+}
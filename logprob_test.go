@@ -0,0 +1,28 @@
+package discreteprobability
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestLogProb(t *testing.T) {
+	g, _ := New([]string{"a", "b", "c"}, []float64{0.25, 0.75, 0})
+	if lp, err := g.LogProb("a"); err != nil || math.Abs(lp-math.Log(0.25)) > 1e-9 {
+		t.Errorf("expected log(0.25), got %v %v", lp, err)
+	}
+	if lp, err := g.LogProb("c"); err != nil || !math.IsInf(lp, -1) {
+		t.Errorf("expected -Inf, got %v %v", lp, err)
+	}
+	if _, err := g.LogProb("d"); err != ErrValue {
+		t.Errorf("expected ErrValue, got %v", err)
+	}
+}
+
+func TestLogProbExact(t *testing.T) {
+	tiny := big.NewRat(1, 100000000000000000)
+	g, _ := NewExact([]string{"common", "rare"}, []*big.Rat{new(big.Rat).Sub(big.NewRat(1, 1), tiny), tiny})
+	if lp, err := g.LogProb("rare"); err != nil || math.Abs(lp-math.Log(1e-17)) > 1e-9 {
+		t.Errorf("expected log(1e-17), got %v %v", lp, err)
+	}
+}
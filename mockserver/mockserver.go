@@ -0,0 +1,101 @@
+// Package mockserver is an http.Handler which replies with canned responses drawn with weights,
+// for the resilience testing of clients.
+// Example usage:
+//
+//		h, err := mockserver.New([]mockserver.Response{
+//			{Status: http.StatusOK, Body: `{"ok":true}`},
+//			{Status: http.StatusServiceUnavailable},
+//			{Status: http.StatusOK, Body: `{"ok":true}`, Delay: 2 * time.Second},
+//		}, []float64{0.9, 0.05, 0.05})
+//		if err != nil {
+//			panic(err) // Error handlers
+//		}
+//		server := httptest.NewServer(h)
+//
+//		90% of the requests succeed at once, 5% fail and 5% are slow.
+package mockserver
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+// Response is a canned response.
+type Response struct {
+	// Status is the status code, 200 if it's 0
+	Status int
+	// Header is added to the headers of the response
+	Header http.Header
+	// Body is the body of the response
+	Body string
+	// Delay is the time to wait before the response, unless the request is canceled
+	Delay time.Duration
+}
+
+// Handler replies with a response drawn with the weights. It's safe for concurrent use.
+type Handler struct {
+	mu        sync.Mutex
+	responses []Response
+	g         *discreteprobability.Generator
+}
+
+// New returns a new Handler of the responses with their weights.
+// It will return error if there are no responses, responses and weights have different length
+// or the weights are invalid, as for discreteprobability.NewNormalized
+func New(responses []Response, weights []float64) (*Handler, error) {
+	if len(responses) == 0 && len(weights) == 0 {
+		return nil, discreteprobability.ErrEmptyInput
+	}
+	indexes := make([]int, len(responses))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	g, err := discreteprobability.NewNormalized(indexes, weights)
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{responses: append([]Response(nil), responses...), g: g}, nil
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (h *Handler) SetSeed(s int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.g.SetSeed(s)
+}
+
+// Next returns the response of the next request.
+func (h *Handler) Next() Response {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.responses[h.g.RandomInt()]
+}
+
+// ServeHTTP replies with a response drawn with the weights.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	resp := h.Next()
+	if resp.Delay > 0 {
+		timer := time.NewTimer(resp.Delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	for key, values := range resp.Header {
+		for _, v := range values {
+			w.Header().Add(key, v)
+		}
+	}
+	status := resp.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	w.Write([]byte(resp.Body))
+}
@@ -0,0 +1,48 @@
+package mockserver
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+func TestHandler(t *testing.T) {
+	h, err := New([]Response{
+		{Body: "ok", Header: http.Header{"Content-Type": {"text/plain"}}},
+		{Status: http.StatusServiceUnavailable},
+	}, []float64{75, 25})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+	h.SetSeed(1)
+
+	status := map[int]int{}
+	for i := 0; i < 10000; i++ {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+		status[w.Code]++
+		if w.Code == http.StatusOK {
+			body, _ := io.ReadAll(w.Body)
+			if string(body) != "ok" || w.Header().Get("Content-Type") != "text/plain" {
+				t.Errorf("unexpected response %q %v", body, w.Header())
+				t.FailNow()
+			}
+		}
+	}
+	if n := status[http.StatusServiceUnavailable]; n < 2300 || n > 2700 {
+		t.Errorf("expected about 2500 failures, got %v", n)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	if _, err := New([]Response{{}}, []float64{1, 1}); err != discreteprobability.ErrLength {
+		t.Errorf("expected ErrLength, got %v", err)
+	}
+	if _, err := New(nil, nil); err != discreteprobability.ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+}
@@ -0,0 +1,24 @@
+package discreteprobability
+
+// NewFromOdds returns a new Generator from fractional odds against each value,
+// e.g. 3 for 3:1 which is an implied probability of 1/(3+1). The implied
+// probabilities are normalized, so the bookmaker's margin is removed.
+// For logits use NewSoftmax with temperature 1.
+// It will return error if values and odds have different length or any of the odds is negative
+func NewFromOdds(v interface{}, odds []float64) (*Generator, error) {
+	weights := make([]float64, len(odds))
+	for i, o := range odds {
+		if o < 0 {
+			return nil, ErrNegativeWeight
+		}
+		weights[i] = 1 / (o + 1)
+	}
+
+	if len(weights) != 0 {
+		var err error
+		if weights, err = normalize(weights); err != nil {
+			return nil, err
+		}
+	}
+	return New(v, weights)
+}
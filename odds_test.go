@@ -0,0 +1,28 @@
+package discreteprobability
+
+import "testing"
+
+func TestNewFromOdds(t *testing.T) {
+	g, err := NewFromOdds([]string{"favorite", "outsider"}, []float64{1.0 / 3, 3})
+	if err != nil {
+		t.Errorf("NewFromOdds error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(g, "favorite", 0.75) || !weightEqual(g, "outsider", 0.25) {
+		t.Errorf("unexpected generator %v", g)
+	}
+
+	// implied probabilities 0.5 + 0.5 + 0.25 include a margin
+	g, err = NewFromOdds([]int{1, 2, 3}, []float64{1, 1, 3})
+	if err != nil {
+		t.Errorf("NewFromOdds error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(g, 1, 0.4) || !weightEqual(g, 3, 0.2) {
+		t.Errorf("unexpected generator %v", g)
+	}
+
+	if _, err := NewFromOdds([]int{1}, []float64{-1}); err != ErrNegativeWeight {
+		t.Errorf("expected ErrNegativeWeight, got %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package discreteprobability
+
+// WithOnDraw sets a callback which is called on every draw with the index, the value and its
+// probability, and returns g. It's for logging, tracing or metering the draws in one place.
+// Every method which returns a value drawn with the weights is a draw, including SampleN,
+// the samplers built on g and the copies of Freeze, Sharded and Locked; InverseCDF, Verify
+// and the deterministic allocations like Allocate and Interleave are not. The index is the
+// position of the value in the generator, which is sorted by weight. The callback is called
+// on the goroutine of the draw, so it should be safe for concurrent use with Freeze and Sharded.
+func (g *Generator) WithOnDraw(f func(index int, v interface{}, p float64)) *Generator {
+	g.onDraw = f
+	return g
+}
+
+// observe runs the hooks of a draw of the value at index i: the callback of WithOnDraw,
+// the tracer and the logger. Every draw goes through it. A draw of a batch is not traced
+// on its own, as the batch is traced as a single event.
+func (g *Generator) observe(i int, batched bool) {
+	if g.onDraw != nil {
+		g.onDraw(i, g.values[i].Interface(), g.probability(i))
+	}
+	if g.tracer != nil && !batched {
+		g.traceDraw(i)
+	}
+	if g.logger != nil {
+		g.logDraw(i)
+	}
+}
@@ -0,0 +1,39 @@
+package discreteprobability
+
+import (
+	"testing"
+)
+
+func TestWithOnDraw(t *testing.T) {
+	g, err := New([]string{"a", "b", "c"}, []float64{0.2, 0.3, 0.5})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+
+	var draws []interface{}
+	g.WithOnDraw(func(index int, v interface{}, p float64) {
+		if g.values[index].Interface() != v || !weightEqual(g, v, p) {
+			t.Errorf("unexpected draw %v %v %v", index, v, p)
+		}
+		draws = append(draws, v)
+	})
+
+	v := g.RandomString()
+	w, _ := g.RandomWeighted()
+	samples := g.SampleN(100)
+	if len(draws) != 102 || draws[0] != v || draws[1] != w {
+		t.Errorf("unexpected draws %v", draws)
+		t.FailNow()
+	}
+	count := map[interface{}]int{}
+	for i, s := range samples {
+		count[s]++
+		count[draws[i+2]]--
+	}
+	for v, n := range count {
+		if n != 0 {
+			t.Errorf("value %v sampled and reported differently by %v", v, n)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Package otelsampler is a weighted head sampling strategy for OpenTelemetry tracing: a span
+// is sampled with the rate of the value of one of its attributes, e.g. the rate of its route.
+// The package doesn't depend on the OpenTelemetry SDK, the adapter to sdktrace.Sampler is
+//
+//		type adapter struct{ s *otelsampler.Sampler }
+//
+//		func (a adapter) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+//			attributes := make(map[string]string, len(p.Attributes))
+//			for _, kv := range p.Attributes {
+//				attributes[string(kv.Key)] = kv.Value.Emit()
+//			}
+//			decision := sdktrace.Drop
+//			if a.s.ShouldSample(attributes) {
+//				decision = sdktrace.RecordAndSample
+//			}
+//			return sdktrace.SamplingResult{
+//				Decision:   decision,
+//				Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+//			}
+//		}
+//
+//		func (a adapter) Description() string { return a.s.Description() }
+//
+// Wrapped in sdktrace.ParentBased, the decision is made at the root span and kept by the rest of the trace.
+// Example usage:
+//
+//		s, err := otelsampler.New("http.route", map[string]float64{"/health": 0.01, "/checkout": 1}, 0.1)
+//		if err != nil {
+//			panic(err) // Error handlers
+//		}
+//		provider := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.ParentBased(adapter{s})))
+//
+//		All the checkouts are traced, 1% of the health checks and 10% of the other routes.
+package otelsampler
+
+import (
+	"github.com/peterli110/discreteprobability"
+)
+
+// Sampler decides whether to sample a span by the value of an attribute.
+// It's safe for concurrent use.
+type Sampler struct {
+	attribute string
+	events    *discreteprobability.EventSampler
+}
+
+// New returns a new Sampler which samples a span with the rate of the value of its attribute,
+// and with defaultRate if the span doesn't have the attribute or it has no rate.
+// It will return discreteprobability.ErrProbability if a rate is out of range [0, 1]
+func New(attribute string, rates map[string]float64, defaultRate float64) (*Sampler, error) {
+	events, err := discreteprobability.NewEventSampler(rates, defaultRate)
+	if err != nil {
+		return nil, err
+	}
+	return &Sampler{attribute: attribute, events: events}, nil
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (s *Sampler) SetSeed(seed int64) {
+	s.events.SetSeed(seed)
+}
+
+// ShouldSample returns whether to sample the span with the attributes.
+func (s *Sampler) ShouldSample(attributes map[string]string) bool {
+	value, ok := attributes[s.attribute]
+	if !ok {
+		return s.events.ShouldKeepDefault()
+	}
+	return s.events.ShouldKeep(value)
+}
+
+// SetRates replaces the rates, e.g. from a remote configuration.
+// It will return discreteprobability.ErrProbability if a rate is out of range [0, 1]
+func (s *Sampler) SetRates(rates map[string]float64, defaultRate float64) error {
+	return s.events.SetRates(rates, defaultRate)
+}
+
+// Description returns the description of the sampler, as in sdktrace.Sampler.
+func (s *Sampler) Description() string {
+	return "WeightedSampler{" + s.attribute + "}"
+}
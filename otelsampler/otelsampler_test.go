@@ -0,0 +1,61 @@
+package otelsampler
+
+import (
+	"testing"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+func TestSampler(t *testing.T) {
+	s, err := New("http.route", map[string]float64{"/health": 0, "/checkout": 1}, 0.5)
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+	s.SetSeed(1)
+
+	sampled := 0
+	for i := 0; i < 10000; i++ {
+		if s.ShouldSample(map[string]string{"http.route": "/health"}) {
+			t.Errorf("health check sampled")
+			t.FailNow()
+		}
+		if !s.ShouldSample(map[string]string{"http.route": "/checkout"}) {
+			t.Errorf("checkout not sampled")
+			t.FailNow()
+		}
+		if s.ShouldSample(nil) {
+			sampled++
+		}
+	}
+	if sampled < 4800 || sampled > 5200 {
+		t.Errorf("expected about 5000 spans sampled with the default rate, got %v", sampled)
+	}
+
+	if err := s.SetRates(nil, 0); err != nil {
+		t.Errorf("SetRates error %v", err)
+	}
+	if s.ShouldSample(map[string]string{"http.route": "/checkout"}) {
+		t.Errorf("checkout sampled after the update")
+	}
+	if s.Description() != "WeightedSampler{http.route}" {
+		t.Errorf("unexpected description %v", s.Description())
+	}
+}
+
+func TestSamplerDefaultRate(t *testing.T) {
+	// no route value collides with the default rate
+	s, _ := New("http.route", map[string]float64{"\x00": 1}, 0)
+	for i := 0; i < 100; i++ {
+		if s.ShouldSample(nil) {
+			t.Errorf("span without the attribute sampled")
+			t.FailNow()
+		}
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	if _, err := New("http.route", map[string]float64{"/": 2}, 1); err != discreteprobability.ErrProbability {
+		t.Errorf("expected ErrProbability, got %v", err)
+	}
+}
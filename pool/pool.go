@@ -0,0 +1,177 @@
+// Package pool runs a mix of tasks on a pool of goroutines, each worker running the tasks
+// drawn with their weights one after the other, e.g. for the workload mix of a soak test.
+// Example usage:
+//
+//		p, err := pool.New(8, map[string]pool.WeightedTask{
+//			"read":  {Weight: 80, Run: read},
+//			"write": {Weight: 20, Run: write},
+//		})
+//		if err != nil {
+//			panic(err) // Error handlers
+//		}
+//		p.Start(ctx)
+//		time.Sleep(time.Hour)
+//		shutdown, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//		defer cancel()
+//		err = p.Shutdown(shutdown)
+package pool
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+// ErrWorkers is returned when the number of workers is not positive
+var ErrWorkers = errors.New("number of workers is not positive")
+
+// WeightedTask is a task with its relative weight in the mix, e.g. a percentage.
+type WeightedTask struct {
+	Weight float64
+	Run    func(ctx context.Context) error
+}
+
+// Stats is the number of runs of a task, and how many of them returned an error.
+type Stats struct {
+	Runs   int
+	Errors int
+}
+
+// Pool runs the tasks. It's safe for concurrent use.
+type Pool struct {
+	workers int
+	names   []string
+	tasks   map[string]WeightedTask
+
+	mu     sync.Mutex
+	g      *discreteprobability.Generator
+	stats  map[string]Stats
+	stop   chan struct{}
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New returns a new Pool of workers goroutines over the tasks. It will return ErrWorkers if workers
+// is not positive, or the error of discreteprobability.NewNormalized if the weights are invalid
+func New(workers int, tasks map[string]WeightedTask) (*Pool, error) {
+	if workers <= 0 {
+		return nil, ErrWorkers
+	}
+	names := make([]string, 0, len(tasks))
+	for name := range tasks {
+		names = append(names, name)
+	}
+	// map iteration order is random, sort the tasks so a seeded pool is reproducible
+	sort.Strings(names)
+	weights := make([]float64, len(names))
+	for i, name := range names {
+		weights[i] = tasks[name].Weight
+	}
+
+	g, err := discreteprobability.NewNormalized(names, weights)
+	if err != nil {
+		return nil, err
+	}
+	g.SetSeed(rand.New(rand.NewSource(time.Now().UnixNano())).Int63())
+	return &Pool{
+		workers: workers,
+		names:   names,
+		tasks:   tasks,
+		g:       g,
+		stats:   make(map[string]Stats, len(names)),
+	}, nil
+}
+
+// SetSeed is to set a custom random seed other than the time stamp. The order of the tasks of
+// the workers together is reproducible, not which worker runs which task.
+func (p *Pool) SetSeed(s int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.g.SetSeed(s)
+}
+
+// Start starts the workers, which run the tasks until Shutdown. The tasks are run with a context
+// derived from ctx, which is canceled if the shutdown times out. Start should be called once.
+func (p *Pool) Start(ctx context.Context) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ctx, p.cancel = context.WithCancel(ctx)
+	p.stop = make(chan struct{})
+	for i := 0; i < p.workers; i++ {
+		p.wg.Add(1)
+		go p.work(ctx, p.stop)
+	}
+}
+
+// work runs the tasks until stop is closed or ctx is done.
+func (p *Pool) work(ctx context.Context, stop <-chan struct{}) {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		p.mu.Lock()
+		name := p.g.RandomString()
+		p.mu.Unlock()
+		err := p.tasks[name].Run(ctx)
+
+		p.mu.Lock()
+		s := p.stats[name]
+		s.Runs++
+		if err != nil {
+			s.Errors++
+		}
+		p.stats[name] = s
+		p.mu.Unlock()
+	}
+}
+
+// Shutdown stops the workers from starting new tasks and waits for the running ones to finish.
+// If ctx is done first, the context of the tasks is canceled and the error of ctx is returned
+// once they have returned.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	stop, cancel := p.stop, p.cancel
+	p.stop = nil
+	p.mu.Unlock()
+	if stop == nil {
+		return nil
+	}
+	close(stop)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// Stats returns the stats of every task by name.
+func (p *Pool) Stats() map[string]Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := make(map[string]Stats, len(p.names))
+	for _, name := range p.names {
+		stats[name] = p.stats[name]
+	}
+	return stats
+}
@@ -0,0 +1,79 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+func TestPool(t *testing.T) {
+	fail := errors.New("failed")
+	p, err := New(4, map[string]WeightedTask{
+		"read":  {Weight: 75, Run: func(ctx context.Context) error { return nil }},
+		"write": {Weight: 25, Run: func(ctx context.Context) error { return fail }},
+	})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+	p.SetSeed(1)
+	p.Start(context.Background())
+	for {
+		stats := p.Stats()
+		if stats["read"].Runs+stats["write"].Runs >= 10000 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown error %v", err)
+	}
+
+	stats := p.Stats()
+	total := float64(stats["read"].Runs + stats["write"].Runs)
+	if share := float64(stats["write"].Runs) / total; share < 0.23 || share > 0.27 {
+		t.Errorf("expected 25%% writes, got %f", share)
+	}
+	if stats["write"].Errors != stats["write"].Runs || stats["read"].Errors != 0 {
+		t.Errorf("unexpected errors %v", stats)
+	}
+	// no task runs after the shutdown
+	if after := p.Stats(); after["read"] != stats["read"] {
+		t.Errorf("tasks ran after the shutdown")
+	}
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	started := make(chan struct{}, 2)
+	p, _ := New(2, map[string]WeightedTask{
+		"slow": {Weight: 1, Run: func(ctx context.Context) error {
+			started <- struct{}{}
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+	})
+	p.Start(context.Background())
+	<-started
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := p.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+	if s := p.Stats()["slow"]; s.Runs != 2 || s.Errors != 2 {
+		t.Errorf("expected 2 canceled runs, got %v", s)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	if _, err := New(0, map[string]WeightedTask{"a": {Weight: 1}}); err != ErrWorkers {
+		t.Errorf("expected ErrWorkers, got %v", err)
+	}
+	if _, err := New(1, map[string]WeightedTask{"a": {Weight: -1}}); err != discreteprobability.ErrNegativeWeight {
+		t.Errorf("expected ErrNegativeWeight, got %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package discreteprobability
+
+import "reflect"
+
+// Posterior returns a new Generator whose weights are the Dirichlet-multinomial posterior mean,
+// with the weights of g as the prior mean and priorStrength as the prior pseudo-count:
+// (priorStrength*p + count) / (priorStrength + total). The observations should be a map from
+// the value to the count, e.g. map[string]int, and values not in g are ignored.
+// The random stream of the new Generator is seeded from g.
+// It will return error if observations is not such a map or any count or priorStrength is negative
+func (g *Generator) Posterior(observations interface{}, priorStrength float64) (*Generator, error) {
+	val := reflect.ValueOf(observations)
+	if val.Kind() != reflect.Map {
+		return nil, ErrNotMap
+	}
+	switch val.Type().Elem().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+	default:
+		return nil, ErrType
+	}
+	if priorStrength < 0 {
+		return nil, ErrNegativeWeight
+	}
+
+	weights := make([]float64, g.size)
+	for i, value := range g.values {
+		weights[i] = priorStrength * g.probability(i)
+		if !value.Type().AssignableTo(val.Type().Key()) {
+			continue
+		}
+		if count := val.MapIndex(value); count.IsValid() {
+			if count.Int() < 0 {
+				return nil, ErrNegativeWeight
+			}
+			weights[i] += float64(count.Int())
+		}
+	}
+
+	weights, err := normalize(weights)
+	if err != nil {
+		return nil, err
+	}
+	return g.derive(weights)
+}
@@ -0,0 +1,28 @@
+package discreteprobability
+
+import "testing"
+
+func TestPosterior(t *testing.T) {
+	g, _ := New([]string{"a", "b"}, []float64{0.5, 0.5})
+	p, err := g.Posterior(map[string]int{"a": 8, "c": 100}, 2)
+	if err != nil {
+		t.Errorf("Posterior error %v", err)
+		t.FailNow()
+	}
+	// (2*0.5 + 8) / 10 and (2*0.5 + 0) / 10
+	if !weightEqual(p, "a", 0.9) || !weightEqual(p, "b", 0.1) {
+		t.Errorf("unexpected posterior %v", p)
+	}
+
+	p, err = g.Posterior(map[string]int{}, 2)
+	if err != nil || !p.Equal(g) {
+		t.Errorf("expected the prior without observations, got %v %v", p, err)
+	}
+
+	if _, err := g.Posterior(map[string]float64{"a": 1}, 1); err != ErrType {
+		t.Errorf("expected ErrType, got %v", err)
+	}
+	if _, err := g.Posterior(map[string]int{"a": -1}, 1); err != ErrNegativeWeight {
+		t.Errorf("expected ErrNegativeWeight, got %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+package discreteprobability
+
+import (
+	"encoding/binary"
+	"math"
+	"reflect"
+)
+
+// Field numbers and wire types of discreteprobability.proto
+const (
+	protoValues      = 1
+	protoWeights     = 2
+	protoIntValue    = 1
+	protoFloatValue  = 2
+	protoStringValue = 3
+
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// ToProto encodes the generator as the Generator message of discreteprobability.proto.
+// It will return ErrType if the values are not ints, floats or strings.
+func (g *Generator) ToProto() ([]byte, error) {
+	var b []byte
+	for _, value := range g.values {
+		var v []byte
+		switch value.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			v = appendTag(v, protoIntValue, wireVarint)
+			v = binary.AppendUvarint(v, uint64(value.Int()))
+		case reflect.Float32, reflect.Float64:
+			v = appendTag(v, protoFloatValue, wireFixed64)
+			v = binary.LittleEndian.AppendUint64(v, math.Float64bits(value.Float()))
+		case reflect.String:
+			v = appendTag(v, protoStringValue, wireBytes)
+			v = appendBytes(v, []byte(value.String()))
+		default:
+			return nil, ErrType
+		}
+
+		b = appendTag(b, protoValues, wireBytes)
+		b = appendBytes(b, v)
+	}
+
+	weights := make([]byte, 0, 8*g.size)
+	for i := 0; i < g.size; i++ {
+		weights = binary.LittleEndian.AppendUint64(weights, math.Float64bits(g.probability(i)))
+	}
+	b = appendTag(b, protoWeights, wireBytes)
+	b = appendBytes(b, weights)
+	return b, nil
+}
+
+// FromProto decodes a Generator message of discreteprobability.proto into a new Generator.
+// It will return ErrSpec if the message is malformed, ErrType if the values have
+// different kinds, or any error of New.
+func FromProto(b []byte) (*Generator, error) {
+	var ints []int
+	var floats []float64
+	var texts []string
+	var weights []float64
+	kind := 0
+
+	err := readFields(b, func(field int, wire int, data []byte, n uint64) error {
+		switch {
+		case field == protoValues && wire == wireBytes:
+			return readFields(data, func(field int, wire int, data []byte, n uint64) error {
+				if kind != 0 && kind != field {
+					return ErrType
+				}
+				kind = field
+				switch {
+				case field == protoIntValue && wire == wireVarint:
+					ints = append(ints, int(int64(n)))
+				case field == protoFloatValue && wire == wireFixed64:
+					floats = append(floats, math.Float64frombits(n))
+				case field == protoStringValue && wire == wireBytes:
+					texts = append(texts, string(data))
+				default:
+					return ErrSpec
+				}
+				return nil
+			})
+		case field == protoWeights && wire == wireFixed64:
+			weights = append(weights, math.Float64frombits(n))
+		case field == protoWeights && wire == wireBytes:
+			// packed repeated double
+			if len(data)%8 != 0 {
+				return ErrSpec
+			}
+			for i := 0; i < len(data); i += 8 {
+				weights = append(weights, math.Float64frombits(binary.LittleEndian.Uint64(data[i:])))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var values interface{}
+	switch kind {
+	case protoIntValue:
+		values = ints
+	case protoFloatValue:
+		values = floats
+	default:
+		if texts == nil {
+			texts = []string{}
+		}
+		values = texts
+	}
+	return New(values, weights)
+}
+
+func appendTag(b []byte, field int, wire int) []byte {
+	return binary.AppendUvarint(b, uint64(field)<<3|uint64(wire))
+}
+
+func appendBytes(b []byte, data []byte) []byte {
+	b = binary.AppendUvarint(b, uint64(len(data)))
+	return append(b, data...)
+}
+
+// readFields calls fn for each field of the message b. For length-delimited fields
+// data is the payload, otherwise n is the varint or fixed value.
+func readFields(b []byte, fn func(field int, wire int, data []byte, n uint64) error) error {
+	for len(b) > 0 {
+		tag, k := binary.Uvarint(b)
+		if k <= 0 {
+			return ErrSpec
+		}
+		b = b[k:]
+
+		field, wire := int(tag>>3), int(tag&7)
+		var data []byte
+		var n uint64
+		switch wire {
+		case wireVarint:
+			n, k = binary.Uvarint(b)
+			if k <= 0 {
+				return ErrSpec
+			}
+			b = b[k:]
+		case wireFixed64:
+			if len(b) < 8 {
+				return ErrSpec
+			}
+			n = binary.LittleEndian.Uint64(b)
+			b = b[8:]
+		case wireBytes:
+			n, k = binary.Uvarint(b)
+			if k <= 0 || uint64(len(b)-k) < n {
+				return ErrSpec
+			}
+			data = b[k : k+int(n)]
+			b = b[k+int(n):]
+		case wireFixed32:
+			if len(b) < 4 {
+				return ErrSpec
+			}
+			n = uint64(binary.LittleEndian.Uint32(b))
+			b = b[4:]
+		default:
+			return ErrSpec
+		}
+
+		if err := fn(field, wire, data, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package discreteprobability
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func TestProtoRoundTrip(t *testing.T) {
+	generators := []*Generator{
+		generateInt(t, 1, sliceLen),
+		generateFloat64(t, 1, sliceLen),
+		generateString(t, 1, sliceLen),
+	}
+	for _, g := range generators {
+		b, err := g.ToProto()
+		if err != nil {
+			t.Errorf("ToProto error %v", err)
+			t.FailNow()
+		}
+		n, err := FromProto(b)
+		if err != nil {
+			t.Errorf("FromProto error %v", err)
+			t.FailNow()
+		}
+		if !g.Equal(n) {
+			t.Errorf("round trip changed the generator: %v", g.Diff(n))
+		}
+	}
+}
+
+func TestFromProtoUnpackedWeights(t *testing.T) {
+	var b []byte
+	for _, v := range []int64{-1, 2} {
+		var value []byte
+		value = appendTag(value, protoIntValue, wireVarint)
+		value = binary.AppendUvarint(value, uint64(v))
+		b = appendTag(b, protoValues, wireBytes)
+		b = appendBytes(b, value)
+	}
+	for _, w := range []float64{0.25, 0.75} {
+		b = appendTag(b, protoWeights, wireFixed64)
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(w))
+	}
+
+	g, err := FromProto(b)
+	if err != nil {
+		t.Errorf("FromProto error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(g, -1, 0.25) || !weightEqual(g, 2, 0.75) {
+		t.Errorf("unexpected generator %v", g)
+	}
+}
+
+func TestFromProtoMalformed(t *testing.T) {
+	if _, err := FromProto([]byte{0x0a, 0x05, 0x08}); err != ErrSpec {
+		t.Errorf("expected ErrSpec, got %v", err)
+	}
+}
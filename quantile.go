@@ -0,0 +1,35 @@
+package discreteprobability
+
+import "math"
+
+// InverseCDF returns the smallest value whose cumulative probability is at least u, the
+// quantile function of a numeric generator, e.g. to draw with the uniforms of a copula
+// or a quasi-Monte Carlo sequence. For u = 0 it's the smallest value with a positive weight.
+// The values are sorted on the first call, and the order is kept for the next ones.
+// It will return ErrProbability if u is out of range [0, 1], or ErrType if the values are not numbers
+func (g *Generator) InverseCDF(u float64) (interface{}, error) {
+	us, err := g.InverseCDFs([]float64{u})
+	if err != nil {
+		return nil, err
+	}
+	return us[0], nil
+}
+
+// InverseCDFs returns the InverseCDF of every u.
+func (g *Generator) InverseCDFs(us []float64) ([]interface{}, error) {
+	for _, u := range us {
+		if !(u >= 0 && u <= 1) {
+			return nil, ErrProbability
+		}
+	}
+	if _, ok := numeric(g.values[0]); !ok {
+		return nil, ErrType
+	}
+
+	order, cdf := g.valueCDF()
+	values := make([]interface{}, len(us))
+	for i, u := range us {
+		values[i] = g.values[order[searchCDF(cdf, math.Max(u, math.SmallestNonzeroFloat64))]].Interface()
+	}
+	return values, nil
+}
@@ -0,0 +1,39 @@
+package discreteprobability
+
+import (
+	"testing"
+)
+
+func TestInverseCDF(t *testing.T) {
+	g, _ := New([]int{30, 10, 20, 0}, []float64{0.5, 0.25, 0.25, 0})
+	tests := []struct {
+		u     float64
+		value int
+	}{
+		{0, 10},
+		{0.1, 10},
+		{0.25, 10},
+		{0.3, 20},
+		{0.5, 20},
+		{0.75, 30},
+		{1, 30},
+	}
+	for _, test := range tests {
+		v, err := g.InverseCDF(test.u)
+		if err != nil {
+			t.Errorf("InverseCDF error %v", err)
+			t.FailNow()
+		}
+		if v != test.value {
+			t.Errorf("InverseCDF of %v expected %v, got %v", test.u, test.value, v)
+		}
+	}
+
+	if _, err := g.InverseCDF(1.5); err != ErrProbability {
+		t.Errorf("expected ErrProbability, got %v", err)
+	}
+	s, _ := New([]string{"a"}, []float64{1})
+	if _, err := s.InverseCDF(0.5); err != ErrType {
+		t.Errorf("expected ErrType, got %v", err)
+	}
+}
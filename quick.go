@@ -0,0 +1,37 @@
+package discreteprobability
+
+import (
+	"math/rand"
+	"reflect"
+)
+
+// AsQuickValue returns a Values function of testing/quick.Config, which fills every argument
+// of the property with a value drawn with the weights, so a property is tested with realistic
+// frequencies of its inputs. The draws use the random source of quick, so a failure is
+// reproducible with its seed. The arguments should all be of the type of the values.
+//
+//	err := quick.Check(property, &quick.Config{Values: g.AsQuickValue()})
+func (g *Generator) AsQuickValue() func(args []reflect.Value, r *rand.Rand) {
+	return func(args []reflect.Value, r *rand.Rand) {
+		for i := range args {
+			j := g.pick(r)
+			g.observe(j, false)
+			args[i] = g.values[j]
+		}
+	}
+}
+
+// AsGopterGen returns a draw from the random source of a property-based testing library.
+// This package doesn't depend on gopter, a gopter.Gen is a single line:
+//
+//	draw := g.AsGopterGen()
+//	gen := gopter.Gen(func(p *gopter.GenParameters) *gopter.GenResult {
+//		return gopter.NewGenResult(draw(p.Rng), gopter.NoShrinker)
+//	})
+func (g *Generator) AsGopterGen() func(r *rand.Rand) interface{} {
+	return func(r *rand.Rand) interface{} {
+		i := g.pick(r)
+		g.observe(i, false)
+		return g.values[i].Interface()
+	}
+}
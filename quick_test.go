@@ -0,0 +1,45 @@
+package discreteprobability
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+func TestAsQuickValue(t *testing.T) {
+	g, _ := New([]int{1, 2, 3}, []float64{0.2, 0, 0.8})
+	occurrence := map[int]float64{}
+	property := func(a, b int) bool {
+		occurrence[a]++
+		occurrence[b]++
+		return a != 2 && b != 2
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: repeats / 2, Values: g.AsQuickValue()}); err != nil {
+		t.Errorf("Check error %v", err)
+		t.FailNow()
+	}
+	for v, w := range map[int]float64{1: 0.2, 3: 0.8} {
+		p := w * repeats
+		if d := p * 3 / 100; occurrence[v] > p+d || occurrence[v] < p-d {
+			t.Errorf("incorrect distribution value %v, expected %f, got %f", v, p, occurrence[v])
+		}
+	}
+}
+
+func TestAsGopterGen(t *testing.T) {
+	g, _ := New([]string{"a", "b"}, []float64{0.25, 0.75})
+	draw := g.AsGopterGen()
+	first := make([]interface{}, 100)
+	r := rand.New(rand.NewSource(1))
+	for i := range first {
+		first[i] = draw(r)
+	}
+	// the same seed of the library gives the same draws
+	r = rand.New(rand.NewSource(1))
+	for i := range first {
+		if v := draw(r); v != first[i] {
+			t.Errorf("draw %v expected %v, got %v", i, first[i], v)
+			t.FailNow()
+		}
+	}
+}
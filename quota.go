@@ -0,0 +1,110 @@
+package discreteprobability
+
+import "sort"
+
+// Quota is the minimum and maximum number of items picked from a group.
+// A zero Max means no maximum.
+type Quota struct {
+	Min int
+	Max int
+}
+
+// SampleWithQuotas picks k distinct items weighted without replacement, while the
+// number of items of each group, given by groupOf, respects its quota.
+// The minimums are filled first, then the rest is picked from the groups under their maximums.
+// It will return ErrQuota if the quotas can't be satisfied.
+func (g *Generator) SampleWithQuotas(k int, quotas map[string]Quota, groupOf func(interface{}) string) ([]interface{}, error) {
+	groups := make([]string, g.size)
+	for i, value := range g.values {
+		groups[i] = groupOf(value.Interface())
+	}
+
+	names := make([]string, 0, len(quotas))
+	minimum := 0
+	for name, quota := range quotas {
+		if quota.Max != 0 && quota.Min > quota.Max {
+			return nil, ErrQuota
+		}
+		minimum += quota.Min
+		names = append(names, name)
+	}
+	if minimum > k {
+		return nil, ErrQuota
+	}
+	// map iteration order is random, sort the names so a seeded generator is reproducible
+	sort.Strings(names)
+
+	buf := g.getPicked(g.size)
+	defer g.putPicked(buf)
+	picked := *buf
+	counts := make(map[string]int, len(quotas))
+	samples := make([]interface{}, 0, k)
+	take := func(eligible func(i int) bool) bool {
+		i := g.drawWithout(picked, eligible)
+		if i < 0 {
+			return false
+		}
+		picked[i] = true
+		counts[groups[i]]++
+		samples = append(samples, g.values[i].Interface())
+		return true
+	}
+
+	for _, name := range names {
+		for n := 0; n < quotas[name].Min; n++ {
+			if !take(func(i int) bool { return groups[i] == name }) {
+				return nil, ErrQuota
+			}
+		}
+	}
+	for len(samples) < k {
+		ok := take(func(i int) bool {
+			quota, ok := quotas[groups[i]]
+			return !ok || quota.Max == 0 || counts[groups[i]] < quota.Max
+		})
+		if !ok {
+			return nil, ErrQuota
+		}
+	}
+	return samples, nil
+}
+
+// drawWithout returns the index of a value drawn from the eligible values which are not picked,
+// or -1 if none of them has a positive weight.
+func (g *Generator) drawWithout(picked []bool, eligible func(i int) bool) int {
+	i := g.searchWithout(picked, eligible)
+	if i >= 0 {
+		g.observe(i, false)
+	}
+	return i
+}
+
+// searchWithout is drawWithout without the hooks.
+func (g *Generator) searchWithout(picked []bool, eligible func(i int) bool) int {
+	sum := float64(0)
+	for i := range g.values {
+		if !picked[i] && eligible(i) {
+			sum += g.probability(i)
+		}
+	}
+	if sum <= 0 {
+		return -1
+	}
+
+	f := uniform(g.source) * sum
+	last := -1
+	for i := range g.values {
+		if picked[i] || !eligible(i) {
+			continue
+		}
+		if p := g.probability(i); p > 0 {
+			last = i
+			if f < p {
+				return i
+			}
+			f -= p
+		}
+	}
+	// rounding of the sum may leave f a little above the last weight
+	return last
+}
@@ -0,0 +1,67 @@
+package discreteprobability
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSampleWithQuotas(t *testing.T) {
+	g := generateInt(t, time.Now().Unix(), sliceLen)
+	groupOf := func(v interface{}) string {
+		if v.(int) < 3 {
+			return "small"
+		}
+		return "large"
+	}
+	quotas := map[string]Quota{
+		"small": {Min: 2},
+		"large": {Max: 2},
+	}
+
+	for n := 0; n < repeats/100; n++ {
+		samples, err := g.SampleWithQuotas(4, quotas, groupOf)
+		if err != nil {
+			t.Errorf("SampleWithQuotas error %v", err)
+			t.FailNow()
+		}
+		if len(samples) != 4 {
+			t.Errorf("expected 4 samples, got %v", samples)
+			t.FailNow()
+		}
+
+		seen := map[int]bool{}
+		counts := map[string]int{}
+		for _, s := range samples {
+			if seen[s.(int)] {
+				t.Errorf("duplicated sample %v", samples)
+				t.FailNow()
+			}
+			seen[s.(int)] = true
+			counts[groupOf(s)]++
+		}
+		if counts["small"] < 2 || counts["large"] > 2 {
+			t.Errorf("quotas not respected %v", samples)
+			t.FailNow()
+		}
+	}
+}
+
+func TestSampleWithQuotasInfeasible(t *testing.T) {
+	g := generateInt(t, 1, sliceLen)
+	groupOf := func(v interface{}) string {
+		if v.(int) < 3 {
+			return "small"
+		}
+		return "large"
+	}
+
+	if _, err := g.SampleWithQuotas(2, map[string]Quota{"small": {Min: 3}}, groupOf); err != ErrQuota {
+		t.Errorf("expected ErrQuota, got %v", err)
+	}
+	if _, err := g.SampleWithQuotas(5, map[string]Quota{"small": {Min: 4}}, groupOf); err != ErrQuota {
+		t.Errorf("expected ErrQuota, got %v", err)
+	}
+	if _, err := g.SampleWithQuotas(5, map[string]Quota{"large": {Max: 1}}, groupOf); err != ErrQuota {
+		t.Errorf("expected ErrQuota, got %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package discreteprobability
+
+import "reflect"
+
+// numeric returns the value as float64, ok is false if the value is not a number.
+func numeric(v reflect.Value) (f float64, ok bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// ProbabilityBetween returns the probability of drawing a value in the range [lo, hi].
+// Values which are not numbers are ignored.
+func (g *Generator) ProbabilityBetween(lo, hi float64) float64 {
+	p := float64(0)
+	for i, value := range g.values {
+		if f, ok := numeric(value); ok && f >= lo && f <= hi {
+			p += g.probability(i)
+		}
+	}
+	return p
+}
+
+// ProbabilityAtLeast returns the probability of drawing a value greater than or equal to x.
+// Values which are not numbers are ignored.
+func (g *Generator) ProbabilityAtLeast(x float64) float64 {
+	p := float64(0)
+	for i, value := range g.values {
+		if f, ok := numeric(value); ok && f >= x {
+			p += g.probability(i)
+		}
+	}
+	return p
+}
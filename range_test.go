@@ -0,0 +1,32 @@
+package discreteprobability
+
+import "testing"
+
+func TestProbabilityRange(t *testing.T) {
+	g, err := New([]int{1, 5, 10, 100}, []float64{0.5, 0.3, 0.15, 0.05})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+
+	cases := []struct {
+		lo, hi, p float64
+	}{
+		{1, 1, 0.5},
+		{1, 10, 0.95},
+		{5, 100, 0.5},
+		{11, 99, 0},
+	}
+	for _, c := range cases {
+		if p := g.ProbabilityBetween(c.lo, c.hi); p < c.p-1e-9 || p > c.p+1e-9 {
+			t.Errorf("ProbabilityBetween(%v, %v) expected %v, got %v", c.lo, c.hi, c.p, p)
+		}
+	}
+
+	if p := g.ProbabilityAtLeast(10); p < 0.2-1e-9 || p > 0.2+1e-9 {
+		t.Errorf("ProbabilityAtLeast(10) expected 0.2, got %v", p)
+	}
+	if p := g.ProbabilityAtLeast(0); p < 1-1e-9 || p > 1+1e-9 {
+		t.Errorf("ProbabilityAtLeast(0) expected 1, got %v", p)
+	}
+}
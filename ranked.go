@@ -0,0 +1,41 @@
+package discreteprobability
+
+import (
+	"math"
+	"sort"
+)
+
+// RankedDraw returns all the values in a weighted random order of the Plackett-Luce model,
+// as if the values were drawn one by one without replacement. The values with zero
+// weights are placed at the end in a uniformly random order.
+func (g *Generator) RankedDraw() []interface{} {
+	type ranked struct {
+		index int
+		key   float64
+		tie   float64
+	}
+
+	// Efraimidis-Spirakis: sorting by u^(1/w) descending is a Plackett-Luce ranking,
+	// compared in the log space to avoid underflow of tiny weights
+	items := make([]ranked, g.size)
+	for i := range g.values {
+		u := uniform(g.source)
+		key := math.Inf(-1)
+		if p := g.probability(i); p > 0 {
+			key = math.Log(u) / p
+		}
+		items[i] = ranked{index: i, key: key, tie: u}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].key != items[j].key {
+			return items[i].key > items[j].key
+		}
+		return items[i].tie > items[j].tie
+	})
+
+	ranking := make([]interface{}, g.size)
+	for i, item := range items {
+		ranking[i] = g.values[item.index].Interface()
+	}
+	return ranking
+}
@@ -0,0 +1,29 @@
+package discreteprobability
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRankedDraw(t *testing.T) {
+	g, _ := New([]string{"a", "b", "c", "d"}, []float64{0.5, 0.3, 0.2, 0})
+	g.SetSeed(time.Now().Unix())
+
+	first := map[interface{}]float64{}
+	for i := 0; i < repeats; i++ {
+		ranking := g.RankedDraw()
+		if len(ranking) != 4 || ranking[3] != "d" {
+			t.Errorf("unexpected ranking %v", ranking)
+			t.FailNow()
+		}
+		first[ranking[0]]++
+	}
+
+	// the first place follows the weights
+	for value, weight := range map[string]float64{"a": 0.5, "b": 0.3, "c": 0.2} {
+		p := weight * repeats
+		if d := p * 3 / 100; first[value] > p+d || first[value] < p-d {
+			t.Errorf("incorrect first place of %v, expected %f, got %f", value, p, first[value])
+		}
+	}
+}
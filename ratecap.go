@@ -0,0 +1,89 @@
+package discreteprobability
+
+import (
+	"math"
+	"reflect"
+	"time"
+)
+
+// RateCapped is a Generator whose values have caps of draws per second.
+// A value which has exceeded its cap is excluded until it recovers, and the
+// rest of the values are renormalized. Like the Generator, it's not safe for
+// concurrent use, guard it with a mutex to share it.
+type RateCapped struct {
+	g      *Generator
+	limits []float64
+	tokens []float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// WithRateCaps returns a RateCapped over the values of g. The caps should be a map from
+// the value to the draws per second, the element type can be any float64 type such as
+// rate.Limit of golang.org/x/time/rate. Values without a cap are never excluded.
+// Each value can burst up to one second of its cap, and a value with a cap of 0 is never drawn.
+// It will return ErrRate if a cap is negative.
+func (g *Generator) WithRateCaps(caps interface{}) (*RateCapped, error) {
+	val := reflect.ValueOf(caps)
+	if val.Kind() != reflect.Map {
+		return nil, ErrNotMap
+	}
+	if val.Type().Elem().Kind() != reflect.Float64 {
+		return nil, ErrType
+	}
+
+	r := &RateCapped{
+		g:      g,
+		limits: make([]float64, g.size),
+		tokens: make([]float64, g.size),
+		now:    time.Now,
+	}
+	for i, value := range g.values {
+		r.limits[i] = math.Inf(1)
+		if value.Type().AssignableTo(val.Type().Key()) {
+			if limit := val.MapIndex(value); limit.IsValid() {
+				r.limits[i] = limit.Float()
+			}
+			if r.limits[i] < 0 {
+				return nil, ErrRate
+			}
+		}
+		r.tokens[i] = burst(r.limits[i])
+	}
+	r.last = r.now()
+	return r, nil
+}
+
+// burst is the number of draws a value can make at once.
+func burst(limit float64) float64 {
+	if limit == 0 {
+		return 0
+	}
+	return math.Max(limit, 1)
+}
+
+// Random returns a value drawn from the values under their caps.
+// It will return ErrNotEnough if all the values with positive weights are capped.
+func (r *RateCapped) Random() (interface{}, error) {
+	now := r.now()
+	elapsed := now.Sub(r.last).Seconds()
+	r.last = now
+	for i, limit := range r.limits {
+		if !math.IsInf(limit, 1) {
+			r.tokens[i] = math.Min(r.tokens[i]+elapsed*limit, burst(limit))
+		}
+	}
+
+	buf := r.g.getPicked(r.g.size)
+	i := r.g.drawWithout(*buf, func(i int) bool {
+		return r.tokens[i] >= 1
+	})
+	r.g.putPicked(buf)
+	if i < 0 {
+		return nil, ErrNotEnough
+	}
+	if !math.IsInf(r.limits[i], 1) {
+		r.tokens[i]--
+	}
+	return r.g.values[i].Interface(), nil
+}
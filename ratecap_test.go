@@ -0,0 +1,83 @@
+package discreteprobability
+
+import (
+	"testing"
+	"time"
+)
+
+// limit is a float64 type like rate.Limit
+type limit float64
+
+func TestWithRateCaps(t *testing.T) {
+	g, _ := New([]string{"a", "b"}, []float64{0.9, 0.1})
+	g.SetSeed(time.Now().Unix())
+	r, err := g.WithRateCaps(map[string]limit{"a": 2})
+	if err != nil {
+		t.Errorf("WithRateCaps error %v", err)
+		t.FailNow()
+	}
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	count := 0
+	for i := 0; i < 100; i++ {
+		v, err := r.Random()
+		if err != nil {
+			t.Errorf("Random error %v", err)
+			t.FailNow()
+		}
+		if v == "a" {
+			count++
+		}
+	}
+	if count > 2 {
+		t.Errorf("expected at most 2 draws of a within a second, got %v", count)
+	}
+
+	now = now.Add(time.Second)
+	count = 0
+	for i := 0; i < 100; i++ {
+		if v, _ := r.Random(); v == "a" {
+			count++
+		}
+	}
+	if count != 2 {
+		t.Errorf("expected 2 draws of a after a second, got %v", count)
+	}
+}
+
+func TestWithRateCapsExhausted(t *testing.T) {
+	g, _ := New([]string{"a"}, []float64{1})
+	r, _ := g.WithRateCaps(map[string]float64{"a": 1})
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	if _, err := r.Random(); err != nil {
+		t.Errorf("Random error %v", err)
+	}
+	if _, err := r.Random(); err != ErrNotEnough {
+		t.Errorf("expected ErrNotEnough, got %v", err)
+	}
+
+	if _, err := g.WithRateCaps(map[string]int{"a": 1}); err != ErrType {
+		t.Errorf("expected ErrType, got %v", err)
+	}
+	if _, err := g.WithRateCaps(map[string]float64{"a": -1}); err != ErrRate {
+		t.Errorf("expected ErrRate, got %v", err)
+	}
+}
+
+func TestWithRateCapsZero(t *testing.T) {
+	g, _ := New([]string{"a", "b"}, []float64{0.5, 0.5})
+	r, _ := g.WithRateCaps(map[string]float64{"a": 0})
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	for i := 0; i < 100; i++ {
+		now = now.Add(time.Second)
+		if v, err := r.Random(); err != nil || v != "b" {
+			t.Errorf("expected b with a capped at 0, got %v %v", v, err)
+			t.FailNow()
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package discreteprobability
+
+// Refit returns a new Generator whose weights are fitted to the elite samples, the update
+// step of the cross-entropy method: the new probability of a value is its frequency in the
+// elites, mixed with its current probability by smoothing, which is the share of the current
+// weights kept. A smoothing of 0 fits the elites exactly, and a positive one keeps the values
+// which are not in the elites from dropping to zero at once. The elites should be a slice.
+// The random stream of the new Generator is seeded from g.
+// It will return error if elites is not a slice or is empty, any elite is not one of the values,
+// or smoothing is out of range [0, 1]
+func (g *Generator) Refit(elites interface{}, smoothing float64) (*Generator, error) {
+	if smoothing < 0 || smoothing > 1 {
+		return nil, ErrProbability
+	}
+	samples, err := sliceValues(elites)
+	if err != nil {
+		return nil, err
+	}
+	if len(samples) == 0 {
+		return nil, ErrEmptyInput
+	}
+
+	counts := make([]float64, g.size)
+	for _, sample := range samples {
+		i := indexOf(g.values, sample.Interface())
+		if i < 0 {
+			return nil, ErrValue
+		}
+		counts[i]++
+	}
+
+	weights := make([]float64, g.size)
+	for i := range weights {
+		frequency := counts[i] / float64(len(samples))
+		weights[i] = (1-smoothing)*frequency + smoothing*g.probability(i)
+	}
+	return g.derive(weights)
+}
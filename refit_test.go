@@ -0,0 +1,37 @@
+package discreteprobability
+
+import (
+	"testing"
+)
+
+func TestRefit(t *testing.T) {
+	g, _ := New([]string{"a", "b", "c", "d"}, []float64{0.25, 0.25, 0.25, 0.25})
+
+	r, err := g.Refit([]string{"a", "a", "b", "a"}, 0)
+	if err != nil {
+		t.Errorf("Refit error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(r, "a", 0.75) || !weightEqual(r, "b", 0.25) || !weightEqual(r, "c", 0) {
+		t.Errorf("unexpected generator %v", r)
+	}
+
+	r, err = g.Refit([]string{"a", "a", "b", "a"}, 0.5)
+	if err != nil {
+		t.Errorf("Refit error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(r, "a", 0.5) || !weightEqual(r, "b", 0.25) || !weightEqual(r, "c", 0.125) {
+		t.Errorf("unexpected generator %v", r)
+	}
+
+	if _, err := g.Refit([]string{"e"}, 0); err != ErrValue {
+		t.Errorf("expected ErrValue, got %v", err)
+	}
+	if _, err := g.Refit([]string{}, 0); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := g.Refit([]string{"a"}, 1.5); err != ErrProbability {
+		t.Errorf("expected ErrProbability, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package discreteprobability
+
+import "math/rand"
+
+// RegimeSwitching draws from one of several generators, the regimes, and switches between them
+// with a Markov chain, e.g. the calm and bursty phases of a workload. All the draws share a single
+// random source, so one seed makes the whole sequence reproducible.
+type RegimeSwitching struct {
+	regimes     []*Generator
+	transitions []*Generator
+	current     int
+	source      rand.Source
+}
+
+// NewRegimeSwitching returns a new RegimeSwitching which starts in the first regime. The row i of
+// transition is the probabilities of the next regime after a draw in regime i.
+// It will return error if there are no regimes, transition is not a square matrix of the size of
+// regimes, any regime is nil, or a row has a negative weight or doesn't sum to 1
+func NewRegimeSwitching(regimes []*Generator, transition [][]float64) (*RegimeSwitching, error) {
+	if len(regimes) == 0 {
+		return nil, ErrEmptyInput
+	}
+	if len(transition) != len(regimes) {
+		return nil, ErrLength
+	}
+
+	indexes := make([]int, len(regimes))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	r := &RegimeSwitching{
+		regimes:     append([]*Generator(nil), regimes...),
+		transitions: make([]*Generator, len(regimes)),
+		source:      rand.NewSource(seed),
+	}
+	for i, row := range transition {
+		if regimes[i] == nil {
+			return nil, ErrEmptyInput
+		}
+		for _, p := range row {
+			if p < 0 {
+				return nil, ErrNegativeWeight
+			}
+		}
+		g, err := New(indexes, append([]float64(nil), row...))
+		if err != nil {
+			return nil, err
+		}
+		r.transitions[i] = g
+	}
+	return r, nil
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (r *RegimeSwitching) SetSeed(s int64) {
+	r.source = rand.NewSource(s)
+}
+
+// Regime returns the index of the current regime.
+func (r *RegimeSwitching) Regime() int {
+	return r.current
+}
+
+// Reset starts over in the first regime.
+func (r *RegimeSwitching) Reset() {
+	r.current = 0
+}
+
+// Random returns a value drawn from the current regime, and then switches to the next regime.
+func (r *RegimeSwitching) Random() interface{} {
+	g := r.regimes[r.current]
+	i := g.pick(r.source)
+	g.observe(i, false)
+	v := g.values[i].Interface()
+
+	t := r.transitions[r.current]
+	r.current = int(t.values[t.pick(r.source)].Int())
+	return v
+}
@@ -0,0 +1,63 @@
+package discreteprobability
+
+import (
+	"testing"
+)
+
+func TestRegimeSwitching(t *testing.T) {
+	calm, _ := New([]int{1}, []float64{1})
+	bursty, _ := New([]int{100}, []float64{1})
+	r, err := NewRegimeSwitching([]*Generator{calm, bursty}, [][]float64{
+		{0.9, 0.1},
+		{0.5, 0.5},
+	})
+	if err != nil {
+		t.Errorf("NewRegimeSwitching error %v", err)
+		t.FailNow()
+	}
+	r.SetSeed(1)
+
+	// the stationary distribution is 5/6 calm and 1/6 bursty, with bursts of 2 draws on average
+	bursts, runs := float64(0), float64(0)
+	previous := 1
+	for i := 0; i < repeats; i++ {
+		v := r.Random().(int)
+		if v == 100 {
+			bursts++
+			if previous != 100 {
+				runs++
+			}
+		}
+		previous = v
+	}
+	if p := repeats / 6.0; bursts > p*1.05 || bursts < p*0.95 {
+		t.Errorf("expected %f bursty draws, got %f", p, bursts)
+	}
+	if mean := bursts / runs; mean < 1.9 || mean > 2.1 {
+		t.Errorf("expected bursts of 2 draws, got %f", mean)
+	}
+
+	r.Reset()
+	if r.Regime() != 0 {
+		t.Errorf("expected the first regime after reset, got %v", r.Regime())
+	}
+}
+
+func TestNewRegimeSwitchingErrors(t *testing.T) {
+	g, _ := New([]int{1}, []float64{1})
+	if _, err := NewRegimeSwitching(nil, nil); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := NewRegimeSwitching([]*Generator{g, g}, [][]float64{{1, 0}}); err != ErrLength {
+		t.Errorf("expected ErrLength, got %v", err)
+	}
+	if _, err := NewRegimeSwitching([]*Generator{g, g}, [][]float64{{1, 0}, {1}}); err != ErrLength {
+		t.Errorf("expected ErrLength, got %v", err)
+	}
+	if _, err := NewRegimeSwitching([]*Generator{g, g}, [][]float64{{1, 0}, {0.5, 0.6}}); err != ErrWeightSum {
+		t.Errorf("expected ErrWeightSum, got %v", err)
+	}
+	if _, err := NewRegimeSwitching([]*Generator{g, g}, [][]float64{{1, 0}, {-0.5, 1.5}}); err != ErrNegativeWeight {
+		t.Errorf("expected ErrNegativeWeight, got %v", err)
+	}
+}
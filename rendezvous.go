@@ -0,0 +1,41 @@
+package discreteprobability
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+)
+
+// RendezvousPick returns the value for the key by weighted rendezvous hashing: every value
+// scores -w/ln(u) with u a hash of the key and the value, and the top score wins. The same key
+// always gives the same value, each value gets the share of its weight of the keys, and when
+// a weight changes or a value is added or removed only the keys to or from that value move,
+// as long as the other weights keep their ratios, e.g. for sharding. Unlike RandomSeeded it
+// doesn't depend on the order of the values. The values are hashed by their fmt %v
+// representation, so it should be distinct.
+func (g *Generator) RendezvousPick(key string) interface{} {
+	best, bestScore := -1, math.Inf(-1)
+	for i, value := range g.values {
+		w := g.probability(i)
+		if w <= 0 {
+			continue
+		}
+
+		h := fnv.New64a()
+		h.Write([]byte(key))
+		h.Write([]byte{0})
+		fmt.Fprint(h, value.Interface())
+		// mix the hash, and map it to a float in (0, 1)
+		mix := splitmix{state: h.Sum64()}
+		u := (float64(mix.Uint64()>>11) + 0.5) / (1 << 53)
+
+		if score := -w / math.Log(u); score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	if best < 0 {
+		return nil
+	}
+	g.observe(best, false)
+	return g.values[best].Interface()
+}
@@ -0,0 +1,48 @@
+package discreteprobability
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestRendezvousPick(t *testing.T) {
+	g, err := New([]string{"a", "b", "c"}, []float64{0.2, 0.3, 0.5})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+
+	picks := make([]interface{}, repeats)
+	occurrence := map[interface{}]float64{}
+	for i := range picks {
+		picks[i] = g.RendezvousPick(strconv.Itoa(i))
+		if picks[i] != g.RendezvousPick(strconv.Itoa(i)) {
+			t.Errorf("key %v got different values", i)
+			t.FailNow()
+		}
+		occurrence[picks[i]]++
+	}
+	for v, w := range map[string]float64{"a": 0.2, "b": 0.3, "c": 0.5} {
+		p := w * repeats
+		if d := p * 3 / 100; occurrence[v] > p+d || occurrence[v] < p-d {
+			t.Errorf("incorrect distribution value %v, expected %f, got %f", v, p, occurrence[v])
+		}
+	}
+
+	// raising the weight of a, with b and c in the same ratio, only moves keys to a
+	g, _ = New([]string{"a", "b", "c"}, []float64{0.44, 0.21, 0.35})
+	moved := 0
+	for i, pick := range picks {
+		v := g.RendezvousPick(strconv.Itoa(i))
+		if v != pick {
+			if v != "a" {
+				t.Errorf("key %v moved from %v to %v", i, pick, v)
+				t.FailNow()
+			}
+			moved++
+		}
+	}
+	if moved == 0 {
+		t.Errorf("expected some keys moved to a")
+	}
+}
@@ -0,0 +1,55 @@
+package discreteprobability
+
+import "reflect"
+
+// MaxRepeat draws from a Generator without returning the same value more than k times
+// in a row, e.g. for a music shuffle. After k repeats the value is excluded for one draw,
+// and the rest of the values are renormalized.
+type MaxRepeat struct {
+	g     *Generator
+	k     int
+	last  interface{}
+	count int
+}
+
+// WithMaxRepeat returns a new MaxRepeat over the values of g.
+// It will return ErrRepeat if k is not positive
+func (g *Generator) WithMaxRepeat(k int) (*MaxRepeat, error) {
+	if k <= 0 {
+		return nil, ErrRepeat
+	}
+	return &MaxRepeat{g: g, k: k}, nil
+}
+
+// Random returns a value which doesn't make a streak of more than k.
+// It will return ErrNotEnough if the repeated value is the only one with a positive weight.
+func (m *MaxRepeat) Random() (interface{}, error) {
+	eligible := func(int) bool { return true }
+	if m.count >= m.k {
+		eligible = func(i int) bool {
+			return !reflect.DeepEqual(m.g.values[i].Interface(), m.last)
+		}
+	}
+
+	buf := m.g.getPicked(m.g.size)
+	defer m.g.putPicked(buf)
+	i := m.g.drawWithout(*buf, eligible)
+	if i < 0 {
+		return nil, ErrNotEnough
+	}
+
+	v := m.g.values[i].Interface()
+	if m.count > 0 && reflect.DeepEqual(v, m.last) {
+		m.count++
+	} else {
+		m.last = v
+		m.count = 1
+	}
+	return v, nil
+}
+
+// Reset forgets the current streak.
+func (m *MaxRepeat) Reset() {
+	m.last = nil
+	m.count = 0
+}
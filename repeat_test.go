@@ -0,0 +1,68 @@
+package discreteprobability
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithMaxRepeat(t *testing.T) {
+	g, err := New([]string{"a", "b"}, []float64{0.9, 0.1})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+	g.SetSeed(time.Now().Unix())
+	m, err := g.WithMaxRepeat(2)
+	if err != nil {
+		t.Errorf("WithMaxRepeat error %v", err)
+		t.FailNow()
+	}
+
+	var last interface{}
+	streak := 0
+	for i := 0; i < repeats/10; i++ {
+		v, err := m.Random()
+		if err != nil {
+			t.Errorf("Random error %v", err)
+			t.FailNow()
+		}
+		if v == last {
+			streak++
+		} else {
+			last = v
+			streak = 1
+		}
+		if streak > 2 {
+			t.Errorf("value %v returned %v times in a row", v, streak)
+			t.FailNow()
+		}
+	}
+
+	if _, err := g.WithMaxRepeat(0); err != ErrRepeat {
+		t.Errorf("expected ErrRepeat, got %v", err)
+	}
+}
+
+func TestWithMaxRepeatNotEnough(t *testing.T) {
+	g, err := New([]string{"a", "b"}, []float64{1, 0})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+	m, err := g.WithMaxRepeat(1)
+	if err != nil {
+		t.Errorf("WithMaxRepeat error %v", err)
+		t.FailNow()
+	}
+	if v, err := m.Random(); err != nil || v != "a" {
+		t.Errorf("expected a, got %v %v", v, err)
+		t.FailNow()
+	}
+	if _, err := m.Random(); err != ErrNotEnough {
+		t.Errorf("expected ErrNotEnough, got %v", err)
+	}
+	m.Reset()
+	if _, err := m.Random(); err != nil {
+		t.Errorf("Random error %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package discreteprobability
+
+import (
+	"math/rand"
+	"time"
+)
+
+// ResampleSystematic returns n indexes into weights drawn with systematic resampling, the
+// resampling step of a particle filter: a single uniform u in [0, 1) gives the points (i+u)/n
+// over the normalized cumulative weights. The weights need not sum to 1. The indexes are in
+// ascending order. It returns nil if n is not positive, any weight is negative or the sum of
+// weights is not positive.
+func ResampleSystematic(weights []float64, n int) []int {
+	return resampleSystematic(rand.NewSource(time.Now().UnixNano()), weights, n)
+}
+
+// ResampleStratified returns n indexes into weights drawn with stratified resampling: the points
+// are (i+u_i)/n with an independent uniform u_i for every i. Otherwise it's as ResampleSystematic.
+func ResampleStratified(weights []float64, n int) []int {
+	return resampleStratified(rand.NewSource(time.Now().UnixNano()), weights, n)
+}
+
+// resampleSystematic is ResampleSystematic with the uniform drawn from source.
+func resampleSystematic(source rand.Source, weights []float64, n int) []int {
+	u := uniform(source)
+	return resample(weights, n, func(int) float64 { return u })
+}
+
+// resampleStratified is ResampleStratified with the uniforms drawn from source.
+func resampleStratified(source rand.Source, weights []float64, n int) []int {
+	return resample(weights, n, func(int) float64 { return uniform(source) })
+}
+
+// resample walks the cumulative weights with the points (i+offset(i))/n.
+func resample(weights []float64, n int, offset func(i int) float64) []int {
+	if n <= 0 {
+		return nil
+	}
+	sum := float64(0)
+	for _, w := range weights {
+		if w < 0 {
+			return nil
+		}
+		sum += w
+	}
+	if sum <= 0 {
+		return nil
+	}
+
+	indexes := make([]int, n)
+	j, cumulative := 0, weights[0]/sum
+	for i := range indexes {
+		point := (float64(i) + offset(i)) / float64(n)
+		// the rounding of the sum may leave the last points a little above the last weight
+		for cumulative <= point && j < len(weights)-1 {
+			j++
+			cumulative += weights[j] / sum
+		}
+		indexes[i] = j
+	}
+	return indexes
+}
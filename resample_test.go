@@ -0,0 +1,49 @@
+package discreteprobability
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestResample(t *testing.T) {
+	weights := []float64{2, 0, 5, 3}
+	for name, resample := range map[string]func(rand.Source, []float64, int) []int{
+		"systematic": resampleSystematic,
+		"stratified": resampleStratified,
+	} {
+		source := rand.NewSource(42)
+		for round := 0; round < 100; round++ {
+			indexes := resample(source, weights, 20)
+			counts := make([]float64, len(weights))
+			for i, index := range indexes {
+				if i > 0 && index < indexes[i-1] {
+					t.Errorf("%v indexes not in ascending order %v", name, indexes)
+					t.FailNow()
+				}
+				counts[index]++
+			}
+			for i, w := range weights {
+				if expected := w / 10 * 20; math.Abs(counts[i]-expected) >= 2 {
+					t.Errorf("%v index %v drawn %v times, expected %v", name, i, counts[i], expected)
+					t.FailNow()
+				}
+			}
+		}
+
+		if a, b := resample(rand.NewSource(1), weights, 20), resample(rand.NewSource(1), weights, 20); !reflect.DeepEqual(a, b) {
+			t.Errorf("%v expected the same indexes with the same seed, got %v and %v", name, a, b)
+		}
+		if indexes := resample(source, []float64{0, 0}, 10); indexes != nil {
+			t.Errorf("%v expected nil for zero weights, got %v", name, indexes)
+		}
+		if indexes := resample(source, []float64{-1, 2}, 10); indexes != nil {
+			t.Errorf("%v expected nil for negative weights, got %v", name, indexes)
+		}
+	}
+
+	if len(ResampleSystematic(weights, 20)) != 20 || len(ResampleStratified(weights, 20)) != 20 {
+		t.Errorf("expected 20 indexes")
+	}
+}
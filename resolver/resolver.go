@@ -0,0 +1,203 @@
+// Package resolver picks endpoints by the weight and priority of SRV records, RFC 2782:
+// the records of the lowest priority are used first, and they are picked with probabilities
+// proportional to their weights, drawn from a discreteprobability.Generator.
+// Example usage:
+//
+//		_, srvs, err := net.LookupSRV("http", "tcp", "example.com")
+//		if err != nil {
+//			panic(err) // Error handlers
+//		}
+//		r, err := resolver.New(resolver.FromSRV(srvs))
+//		if err != nil {
+//			panic(err) // Error handlers
+//		}
+//		record := r.Pick()
+//		addr := net.JoinHostPort(record.Target, strconv.Itoa(int(record.Port)))
+package resolver
+
+import (
+	"math/rand"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+// WeightedRecord is an endpoint with the priority and weight of a SRV record.
+// A lower Priority is preferred.
+type WeightedRecord struct {
+	Target   string
+	Port     uint16
+	Priority uint16
+	Weight   uint16
+}
+
+// FromSRV returns the records of the SRV records of net.LookupSRV.
+func FromSRV(srvs []*net.SRV) []WeightedRecord {
+	records := make([]WeightedRecord, len(srvs))
+	for i, srv := range srvs {
+		records[i] = WeightedRecord{
+			Target:   srv.Target,
+			Port:     srv.Port,
+			Priority: srv.Priority,
+			Weight:   srv.Weight,
+		}
+	}
+	return records
+}
+
+// tier is the records of a priority with the generator of their indexes.
+type tier struct {
+	records []WeightedRecord
+	g       *discreteprobability.Generator
+}
+
+// Resolver picks the records. It's safe for concurrent use.
+type Resolver struct {
+	mu      sync.Mutex
+	tiers   []tier
+	rnd     *rand.Rand
+	ttl     time.Duration
+	refresh func() ([]WeightedRecord, error)
+	updated time.Time
+	now     func() time.Time
+}
+
+// New returns a new Resolver over the records.
+// It will return ErrEmptyInput if there are no records
+func New(records []WeightedRecord) (*Resolver, error) {
+	r := &Resolver{
+		rnd: rand.New(rand.NewSource(time.Now().UnixNano())),
+		now: time.Now,
+	}
+	if err := r.set(records); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (r *Resolver) SetSeed(s int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rnd = rand.New(rand.NewSource(s))
+	for _, t := range r.tiers {
+		t.g.SetSeed(r.rnd.Int63())
+	}
+}
+
+// WithRefresh sets the function which reloads the records, e.g. with net.LookupSRV, and returns r.
+// It's called by Pick once the records are older than ttl. If it returns an error or
+// no records, the old records are kept and it's called again after another ttl.
+func (r *Resolver) WithRefresh(ttl time.Duration, refresh func() ([]WeightedRecord, error)) *Resolver {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ttl = ttl
+	r.refresh = refresh
+	r.updated = r.now()
+	return r
+}
+
+// set groups the records into the tiers of their priorities, from the lowest.
+func (r *Resolver) set(records []WeightedRecord) error {
+	if len(records) == 0 {
+		return discreteprobability.ErrEmptyInput
+	}
+
+	sorted := append([]WeightedRecord(nil), records...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority < sorted[j].Priority
+	})
+	var tiers []tier
+	for start := 0; start < len(sorted); {
+		end := start
+		for end < len(sorted) && sorted[end].Priority == sorted[start].Priority {
+			end++
+		}
+		t, err := r.newTier(sorted[start:end])
+		if err != nil {
+			return err
+		}
+		tiers = append(tiers, t)
+		start = end
+	}
+	r.tiers = tiers
+	return nil
+}
+
+// newTier returns the tier of the records, whose generator draws the records with their weights,
+// or uniformly if all the weights are zero.
+func (r *Resolver) newTier(records []WeightedRecord) (tier, error) {
+	indexes := make([]int, len(records))
+	weights := make([]float64, len(records))
+	sum := float64(0)
+	for i, record := range records {
+		indexes[i] = i
+		weights[i] = float64(record.Weight)
+		sum += weights[i]
+	}
+	if sum == 0 {
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+
+	g, err := discreteprobability.NewNormalized(indexes, weights)
+	if err != nil {
+		return tier{}, err
+	}
+	g.SetSeed(r.rnd.Int63())
+	return tier{records: records, g: g}, nil
+}
+
+// maybeRefresh reloads the records if they are older than the ttl.
+func (r *Resolver) maybeRefresh() {
+	if r.refresh == nil {
+		return
+	}
+	now := r.now()
+	if now.Sub(r.updated) < r.ttl {
+		return
+	}
+	r.updated = now
+	if records, err := r.refresh(); err == nil {
+		// the old records are kept if the new ones are empty
+		r.set(records)
+	}
+}
+
+// Pick returns a record of the lowest priority, drawn with the weights. The records with zero
+// weight are picked only if all the records of the priority have zero weight.
+func (r *Resolver) Pick() WeightedRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.maybeRefresh()
+	t := r.tiers[0]
+	return t.records[t.g.RandomInt()]
+}
+
+// Order returns all the records in the order to try them, e.g. to fail over: by priority from
+// the lowest, and within a priority in a random order drawn with the weights.
+func (r *Resolver) Order() []WeightedRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.maybeRefresh()
+	var order []WeightedRecord
+	for _, t := range r.tiers {
+		values, _ := t.g.Drain(len(t.records))
+		picked := make([]bool, len(t.records))
+		for _, v := range values {
+			order = append(order, t.records[v.(int)])
+			picked[v.(int)] = true
+		}
+		// the records with zero weight are tried last, in their order
+		for i, record := range t.records {
+			if !picked[i] {
+				order = append(order, record)
+			}
+		}
+	}
+	return order
+}
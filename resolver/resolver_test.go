@@ -0,0 +1,97 @@
+package resolver
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+const repeats = 100000
+
+func TestPick(t *testing.T) {
+	r, err := New([]WeightedRecord{
+		{Target: "a", Priority: 10, Weight: 60},
+		{Target: "b", Priority: 10, Weight: 20},
+		{Target: "c", Priority: 10, Weight: 20},
+		{Target: "d", Priority: 10, Weight: 0},
+		{Target: "backup", Priority: 20, Weight: 100},
+	})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+	r.SetSeed(time.Now().Unix())
+
+	occurrence := map[string]float64{}
+	for i := 0; i < repeats; i++ {
+		occurrence[r.Pick().Target]++
+	}
+	if occurrence["d"] != 0 || occurrence["backup"] != 0 {
+		t.Errorf("unexpected picks %v", occurrence)
+	}
+	for target, w := range map[string]float64{"a": 0.6, "b": 0.2, "c": 0.2} {
+		p := w * repeats
+		if d := p * 3 / 100; occurrence[target] > p+d || occurrence[target] < p-d {
+			t.Errorf("incorrect distribution target %v, expected %f, got %f", target, p, occurrence[target])
+		}
+	}
+
+	order := r.Order()
+	if len(order) != 5 || order[3].Target != "d" || order[4].Target != "backup" {
+		t.Errorf("unexpected order %v", order)
+	}
+
+	if _, err := New(nil); err != discreteprobability.ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+}
+
+func TestZeroWeights(t *testing.T) {
+	r, _ := New([]WeightedRecord{{Target: "a"}, {Target: "b"}})
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		seen[r.Pick().Target] = true
+	}
+	if !seen["a"] || !seen["b"] {
+		t.Errorf("expected both records with zero weights, got %v", seen)
+	}
+}
+
+func TestWithRefresh(t *testing.T) {
+	r, _ := New([]WeightedRecord{{Target: "old", Weight: 1}})
+	now := time.Now()
+	r.now = func() time.Time { return now }
+	var records []WeightedRecord
+	var refreshErr error
+	calls := 0
+	r.WithRefresh(time.Minute, func() ([]WeightedRecord, error) {
+		calls++
+		return records, refreshErr
+	})
+
+	if target := r.Pick().Target; target != "old" || calls != 0 {
+		t.Errorf("expected old before the ttl, got %v after %v calls", target, calls)
+	}
+
+	now = now.Add(time.Minute)
+	refreshErr = errors.New("lookup failed")
+	if target := r.Pick().Target; target != "old" || calls != 1 {
+		t.Errorf("expected old after a failed refresh, got %v after %v calls", target, calls)
+	}
+
+	now = now.Add(time.Minute)
+	records, refreshErr = []WeightedRecord{{Target: "new", Weight: 1}}, nil
+	if target := r.Pick().Target; target != "new" || calls != 2 {
+		t.Errorf("expected new after the refresh, got %v after %v calls", target, calls)
+	}
+}
+
+func TestFromSRV(t *testing.T) {
+	records := FromSRV([]*net.SRV{{Target: "a.example.com.", Port: 443, Priority: 1, Weight: 5}})
+	if len(records) != 1 || records[0] != (WeightedRecord{Target: "a.example.com.", Port: 443, Priority: 1, Weight: 5}) {
+		t.Errorf("unexpected records %v", records)
+	}
+}
@@ -0,0 +1,23 @@
+package discreteprobability
+
+// Reweight returns a new Generator over the values of g with the weights transformed by f,
+// which gets each value with its probability, renormalized. It's for boosts, penalties or
+// flooring of the weights in one step. The random stream of the new Generator is seeded from g.
+// It will return ErrNegativeWeight if f returns a negative weight or NaN,
+// or ErrWeightSum if none of the new weights is positive
+func (g *Generator) Reweight(f func(v interface{}, w float64) float64) (*Generator, error) {
+	weights := make([]float64, g.size)
+	for i, value := range g.values {
+		w := f(value.Interface(), g.probability(i))
+		if !(w >= 0) {
+			return nil, ErrNegativeWeight
+		}
+		weights[i] = w
+	}
+
+	weights, err := normalize(weights)
+	if err != nil {
+		return nil, err
+	}
+	return g.derive(weights)
+}
@@ -0,0 +1,41 @@
+package discreteprobability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestReweight(t *testing.T) {
+	g, err := New([]string{"a", "b", "c"}, []float64{0.2, 0.3, 0.5})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+
+	r, err := g.Reweight(func(v interface{}, w float64) float64 {
+		if v == "c" {
+			return 0
+		}
+		return w * 2
+	})
+	if err != nil {
+		t.Errorf("Reweight error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(r, "a", 0.4) || !weightEqual(r, "b", 0.6) || !weightEqual(r, "c", 0) {
+		t.Errorf("unexpected generator %v", r)
+	}
+	if !weightEqual(g, "c", 0.5) {
+		t.Errorf("the generator was modified %v", g)
+	}
+
+	if _, err := g.Reweight(func(interface{}, float64) float64 { return -1 }); err != ErrNegativeWeight {
+		t.Errorf("expected ErrNegativeWeight, got %v", err)
+	}
+	if _, err := g.Reweight(func(interface{}, float64) float64 { return math.NaN() }); err != ErrNegativeWeight {
+		t.Errorf("expected ErrNegativeWeight, got %v", err)
+	}
+	if _, err := g.Reweight(func(interface{}, float64) float64 { return 0 }); err != ErrWeightSum {
+		t.Errorf("expected ErrWeightSum, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package discreteprobability
+
+import (
+	"database/sql"
+	"reflect"
+)
+
+// NewFromRows returns a new Generator while scanning the rows, with the values from
+// the column valueCol and the weights from the column weightCol. Text values are
+// stored as strings and the rows are closed when done.
+// It will return ErrColumn if any of the columns is not found, ErrNull if a value is NULL,
+// ErrType if the values are not all of the same type, or any error of New.
+func NewFromRows(rows *sql.Rows, valueCol, weightCol string) (*Generator, error) {
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	valueIndex, weightIndex := -1, -1
+	for i, column := range columns {
+		switch column {
+		case valueCol:
+			valueIndex = i
+		case weightCol:
+			weightIndex = i
+		}
+	}
+	if valueIndex < 0 || weightIndex < 0 {
+		return nil, ErrColumn
+	}
+
+	var values []reflect.Value
+	var weights []float64
+	dest := make([]interface{}, len(columns))
+	for i := range dest {
+		dest[i] = new(sql.RawBytes)
+	}
+	for rows.Next() {
+		var value interface{}
+		var weight float64
+		dest[valueIndex] = &value
+		dest[weightIndex] = &weight
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		if value == nil {
+			return nil, ErrNull
+		}
+		if b, ok := value.([]byte); ok {
+			value = string(b)
+		}
+		v := reflect.ValueOf(value)
+		if len(values) > 0 && v.Type() != values[0].Type() {
+			return nil, ErrType
+		}
+		values = append(values, v)
+		weights = append(weights, weight)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return newGenerator(values, weights)
+}
@@ -0,0 +1,110 @@
+package discreteprobability
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+)
+
+// fakeDriver serves a fixed table for any query.
+type fakeDriver struct{}
+type fakeConn struct{}
+type fakeStmt struct{}
+type fakeRows struct {
+	row int
+}
+
+var fakeColumns = []string{"id", "item", "weight"}
+var lootTable = [][]driver.Value{
+	{int64(1), []byte("sword"), 0.1},
+	{int64(2), []byte("shield"), 0.3},
+	{int64(3), []byte("potion"), 0.6},
+}
+var fakeTable = lootTable
+
+func init() {
+	sql.Register("discreteprobability", fakeDriver{})
+}
+
+func (fakeDriver) Open(string) (driver.Conn, error)         { return fakeConn{}, nil }
+func (fakeConn) Prepare(string) (driver.Stmt, error)        { return fakeStmt{}, nil }
+func (fakeConn) Close() error                               { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                  { return nil, driver.ErrSkip }
+func (fakeStmt) Close() error                               { return nil }
+func (fakeStmt) NumInput() int                              { return -1 }
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) { return nil, driver.ErrSkip }
+func (fakeStmt) Query([]driver.Value) (driver.Rows, error)  { return &fakeRows{}, nil }
+func (*fakeRows) Columns() []string                         { return fakeColumns }
+func (*fakeRows) Close() error                              { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.row == len(fakeTable) {
+		return io.EOF
+	}
+	copy(dest, fakeTable[r.row])
+	r.row++
+	return nil
+}
+
+func queryFake(t *testing.T) *sql.Rows {
+	db, err := sql.Open("discreteprobability", "")
+	if err != nil {
+		t.Errorf("sql.Open error %v", err)
+		t.FailNow()
+	}
+	rows, err := db.Query("SELECT id, item, weight FROM loot")
+	if err != nil {
+		t.Errorf("Query error %v", err)
+		t.FailNow()
+	}
+	return rows
+}
+
+func TestNewFromRows(t *testing.T) {
+	g, err := NewFromRows(queryFake(t), "item", "weight")
+	if err != nil {
+		t.Errorf("NewFromRows error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(g, "sword", 0.1) || !weightEqual(g, "shield", 0.3) || !weightEqual(g, "potion", 0.6) {
+		t.Errorf("unexpected generator %v", g)
+	}
+	if _, err := g.RandomStringSafe(); err != nil {
+		t.Errorf("RandomStringSafe error %v", err)
+	}
+
+	g, err = NewFromRows(queryFake(t), "id", "weight")
+	if err != nil {
+		t.Errorf("NewFromRows error %v", err)
+		t.FailNow()
+	}
+	if v := g.RandomInt(); v < 1 || v > 3 {
+		t.Errorf("unexpected value %v", v)
+	}
+}
+
+func TestNewFromRowsColumn(t *testing.T) {
+	if _, err := NewFromRows(queryFake(t), "name", "weight"); err != ErrColumn {
+		t.Errorf("expected ErrColumn, got %v", err)
+	}
+}
+
+func TestNewFromRowsInvalid(t *testing.T) {
+	defer func() { fakeTable = lootTable }()
+
+	fakeTable = [][]driver.Value{
+		{int64(1), []byte("sword"), 0.5},
+		{int64(2), nil, 0.5},
+	}
+	if _, err := NewFromRows(queryFake(t), "item", "weight"); err != ErrNull {
+		t.Errorf("expected ErrNull, got %v", err)
+	}
+
+	fakeTable = [][]driver.Value{
+		{int64(1), []byte("sword"), 0.5},
+		{int64(2), int64(7), 0.5},
+	}
+	if _, err := NewFromRows(queryFake(t), "item", "weight"); err != ErrType {
+		t.Errorf("expected ErrType, got %v", err)
+	}
+}
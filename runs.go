@@ -0,0 +1,94 @@
+package discreteprobability
+
+import "math"
+
+// maxRuns is the most runs of equal weights for which the weights are treated as
+// piecewise uniform. With more runs, finding the run costs as much as the search.
+const maxRuns = 4
+
+// run is a range of values with equal weights. Within a run the cumulative weights
+// are evenly spaced, so the index is computed instead of searched.
+type run struct {
+	start  int
+	end    int
+	before float64
+	weight float64
+}
+
+// runs is the piecewise uniform form of the cumulative weights.
+type runs []run
+
+// newRuns returns the runs of equal weights within weightEpsilon of the cumulative weights,
+// or nil if there are too many runs to be worth it. Uniform weights are always a single run.
+func newRuns(cumulative []float64) runs {
+	var r runs
+	before := float64(0)
+	for i, c := range cumulative {
+		w := c - before
+		if n := len(r); n == 0 || abs(w-r[n-1].weight) > weightEpsilon {
+			if n == maxRuns || (n == 1 && len(cumulative) <= linearSize) {
+				return nil
+			}
+			r = append(r, run{start: i, before: before, weight: w})
+		}
+		r[len(r)-1].end = i + 1
+		before = c
+	}
+
+	// the mean weight of the run, so the rounding of the single weights doesn't add up
+	for i := range r {
+		end := cumulative[r[i].end-1]
+		r[i].weight = (end - r[i].before) / float64(r[i].end-r[i].start)
+	}
+	return r
+}
+
+// search returns the index of the first cumulative weight which covers f,
+// or size if there is none. The recomputed end of the last run may round below f = 1,
+// so any f above it is in the last run.
+func (r runs) search(f float64, size int) int {
+	for k, run := range r {
+		if run.weight == 0 {
+			continue
+		}
+		if k < len(r)-1 && f > run.before+run.weight*float64(run.end-run.start) {
+			continue
+		}
+		i := run.start + int(math.Ceil((f-run.before)/run.weight)) - 1
+		if i < run.start {
+			return run.start
+		}
+		if i >= run.end {
+			return run.end - 1
+		}
+		return i
+	}
+	return size
+}
+
+// Algorithm returns how the values are drawn, which is one of
+//
+//	single: the only value is returned without a random number
+//	exact: big integer cumulative weights, see NewExact
+//	uniform: equal weights, the index is computed from the random number
+//	piecewise-uniform: a few runs of equal weights, the index is computed within the run
+//	linear: a linear scan of the cumulative weights for small generators
+//	eytzinger: a search of the cumulative weights in the Eytzinger layout
+//	binary: a binary search of the cumulative weights
+func (g *Generator) Algorithm() string {
+	switch {
+	case g.size == 1:
+		return "single"
+	case g.exact != nil:
+		return "exact"
+	case len(g.runs) == 1:
+		return "uniform"
+	case g.runs != nil:
+		return "piecewise-uniform"
+	case g.size <= linearSize:
+		return "linear"
+	case g.layout != nil:
+		return "eytzinger"
+	}
+	return "binary"
+}
@@ -0,0 +1,103 @@
+package discreteprobability
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestRunsSearch(t *testing.T) {
+	tests := [][]float64{
+		{0.25, 0.25, 0.25, 0.25},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0.5, 0.5},
+		{0.01, 0.01, 0.01, 0.01, 0.01, 0.01, 0.01, 0.01, 0.01, 0.01, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1},
+	}
+	for _, weights := range tests {
+		cumulative := make([]float64, len(weights))
+		sum := float64(0)
+		for i, w := range weights {
+			sum += w
+			cumulative[i] = sum
+		}
+		r := newRuns(cumulative)
+		if r == nil {
+			t.Errorf("expected runs for %v", weights)
+			t.FailNow()
+		}
+
+		g := generateInt(t, time.Now().Unix(), 1)
+		for i := 0; i < repeats/10; i++ {
+			f := uniform(g.source)
+			if i == 0 {
+				f = cumulative[len(cumulative)-2]
+			}
+			expected := sort.Search(len(cumulative), func(i int) bool {
+				return cumulative[i] >= f
+			})
+			if got := r.search(f, len(cumulative)); got != expected {
+				t.Errorf("%v search of %v expected %v, got %v", weights, f, expected, got)
+				t.FailNow()
+			}
+		}
+	}
+}
+
+func TestAlgorithm(t *testing.T) {
+	tests := []struct {
+		weights   []float64
+		algorithm string
+	}{
+		{[]float64{0.5, 0.5}, "uniform"},
+		{[]float64{0.25, 0.75}, "linear"},
+		{[]float64{0.05, 0.05, 0.05, 0.05, 0.05, 0.05, 0.05, 0.05, 0.05, 0.05, 0.05, 0.05, 0.05, 0.05, 0.05, 0.05, 0.1, 0.1}, "piecewise-uniform"},
+		{[]float64{0.01, 0.02, 0.03, 0.04, 0.05, 0.06, 0.07, 0.08, 0.09, 0.1, 0.02, 0.03, 0.04, 0.05, 0.06, 0.07, 0.08, 0.1}, "binary"},
+	}
+	for _, test := range tests {
+		g, err := New(make([]int, len(test.weights)), test.weights)
+		if err != nil {
+			t.Errorf("New error %v", err)
+			t.FailNow()
+		}
+		if a := g.Algorithm(); a != test.algorithm {
+			t.Errorf("%v expected algorithm %v, got %v", test.weights, test.algorithm, a)
+		}
+	}
+
+	g := generateInt(t, time.Now().Unix(), 1000)
+	if a := g.Algorithm(); a != "uniform" {
+		t.Errorf("expected uniform algorithm, got %v", a)
+	}
+	occurrence := map[int]float64{}
+	for i := 0; i < repeats; i++ {
+		occurrence[g.RandomInt()]++
+	}
+	if len(occurrence) != 1000 {
+		t.Errorf("expected all the 1000 values, got %v", len(occurrence))
+	}
+}
+
+// maxSource always returns the largest Int63, for which uniform rounds to exactly 1.
+type maxSource struct{}
+
+func (maxSource) Int63() int64 { return 1<<63 - 1 }
+func (maxSource) Seed(int64)   {}
+
+func TestRunsSearchMaxUniform(t *testing.T) {
+	for _, n := range []int{49, 98, 103, 107, 161, 187} {
+		values := make([]int, n)
+		weights := make([]float64, n)
+		for i := range weights {
+			values[i] = i
+			weights[i] = 1 / float64(n)
+		}
+		g, err := New(values, weights)
+		if err != nil {
+			t.Errorf("New error %v", err)
+			t.FailNow()
+		}
+		g.SetSource(maxSource{})
+		if v := g.RandomInt(); v != n-1 {
+			t.Errorf("%v uniform values expected the last value, got %v", n, v)
+		}
+	}
+}
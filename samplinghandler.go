@@ -0,0 +1,127 @@
+package discreteprobability
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SamplingHandler is a slog.Handler which passes a record to the next handler with a probability,
+// e.g. 1% of the debug records and all the errors. The rates are specs of "value:rate,value:rate",
+// as for Spec, and each rate is drawn with an EventSampler. It's safe for concurrent use.
+type SamplingHandler struct {
+	next      slog.Handler
+	levels    *EventSampler
+	attribute string
+	attrs     *EventSampler
+	// value is the value of the attribute added by WithAttrs, if any
+	value    string
+	hasValue bool
+	grouped  bool
+}
+
+// NewSamplingHandler returns a new SamplingHandler with the rates of levels, e.g. "DEBUG:0.01,INFO:0.1".
+// The levels are parsed as by slog.Level.UnmarshalText, and the levels which are not in the spec
+// are kept. It will return ErrSpec if the spec or a level is malformed, or ErrProbability if
+// a rate is out of range [0, 1]
+func NewSamplingHandler(next slog.Handler, levels string) (*SamplingHandler, error) {
+	names, rates, err := parseSpec(levels)
+	if err != nil {
+		return nil, err
+	}
+	byLevel := make(map[string]float64, len(names))
+	for i, name := range names {
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(name)); err != nil {
+			return nil, ErrSpec
+		}
+		byLevel[level.String()] = rates[i]
+	}
+	sampler, err := NewEventSampler(byLevel, 1)
+	if err != nil {
+		return nil, err
+	}
+	return &SamplingHandler{next: next, levels: sampler}, nil
+}
+
+// WithAttributeRates sets the rates of the values of attribute, e.g. "/health:0.01" for the attribute
+// "route", and returns h. A record with a value of the attribute which is in the spec has the rate of
+// the value instead of the rate of its level. Only the top-level attributes are looked at.
+// It will return ErrSpec if the spec is malformed, or ErrProbability if a rate is out of range [0, 1]
+func (h *SamplingHandler) WithAttributeRates(attribute string, values string) (*SamplingHandler, error) {
+	names, rates, err := parseSpec(values)
+	if err != nil {
+		return nil, err
+	}
+	byValue := make(map[string]float64, len(names))
+	for i, name := range names {
+		byValue[name] = rates[i]
+	}
+	sampler, err := NewEventSampler(byValue, 1)
+	if err != nil {
+		return nil, err
+	}
+	h.attribute, h.attrs = attribute, sampler
+	return h, nil
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (h *SamplingHandler) SetSeed(s int64) {
+	h.levels.SetSeed(s)
+	if h.attrs != nil {
+		h.attrs.SetSeed(s + 1)
+	}
+}
+
+// Enabled reports whether the next handler handles the level.
+func (h *SamplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle passes the record to the next handler with the rate of its attribute value or level.
+func (h *SamplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if h.attrs != nil {
+		value, ok := h.value, h.hasValue
+		if !h.grouped {
+			r.Attrs(func(a slog.Attr) bool {
+				if a.Key == h.attribute {
+					value, ok = a.Value.String(), true
+					return false
+				}
+				return true
+			})
+		}
+		if ok && h.attrs.hasRate(value) {
+			if !h.attrs.ShouldKeep(value) {
+				return nil
+			}
+			return h.next.Handle(ctx, r)
+		}
+	}
+
+	if !h.levels.ShouldKeep(r.Level.String()) {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs returns a SamplingHandler with the same rates whose next handler has the attributes.
+func (h *SamplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	c.next = h.next.WithAttrs(attrs)
+	if h.attrs != nil && !h.grouped {
+		for _, a := range attrs {
+			if a.Key == h.attribute {
+				c.value, c.hasValue = a.Value.String(), true
+			}
+		}
+	}
+	return &c
+}
+
+// WithGroup returns a SamplingHandler with the same rates whose next handler has the group.
+func (h *SamplingHandler) WithGroup(name string) slog.Handler {
+	c := *h
+	c.next = h.next.WithGroup(name)
+	c.grouped = c.grouped || name != ""
+	return &c
+}
@@ -0,0 +1,68 @@
+package discreteprobability
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSamplingHandler(t *testing.T) {
+	var buf bytes.Buffer
+	next := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	h, err := NewSamplingHandler(next, "DEBUG:0.1, INFO:0")
+	if err != nil {
+		t.Errorf("NewSamplingHandler error %v", err)
+		t.FailNow()
+	}
+	h.SetSeed(1)
+	logger := slog.New(h)
+
+	for i := 0; i < 10000; i++ {
+		logger.Debug("d")
+		logger.Info("i")
+		logger.Warn("w")
+	}
+	out := buf.String()
+	if n := strings.Count(out, "msg=d"); n < 900 || n > 1100 {
+		t.Errorf("expected about 1000 debug records, got %v", n)
+	}
+	if n := strings.Count(out, "msg=i"); n != 0 {
+		t.Errorf("expected no info records, got %v", n)
+	}
+	if n := strings.Count(out, "msg=w"); n != 10000 {
+		t.Errorf("expected all the warnings, got %v", n)
+	}
+}
+
+func TestSamplingHandlerAttribute(t *testing.T) {
+	var buf bytes.Buffer
+	h, _ := NewSamplingHandler(slog.NewTextHandler(&buf, nil), "INFO:1")
+	if _, err := h.WithAttributeRates("route", "/health:0"); err != nil {
+		t.Errorf("WithAttributeRates error %v", err)
+		t.FailNow()
+	}
+	logger := slog.New(h)
+
+	logger.Info("health", "route", "/health")
+	logger.With("route", "/health").Info("health")
+	logger.Info("checkout", "route", "/checkout")
+	logger.WithGroup("request").Info("grouped", "route", "/health")
+	out := buf.String()
+	if strings.Contains(out, "msg=health") {
+		t.Errorf("health records not dropped: %v", out)
+	}
+	if !strings.Contains(out, "msg=checkout") || !strings.Contains(out, "msg=grouped") {
+		t.Errorf("records dropped: %v", out)
+	}
+}
+
+func TestNewSamplingHandlerErrors(t *testing.T) {
+	next := slog.NewTextHandler(&bytes.Buffer{}, nil)
+	if _, err := NewSamplingHandler(next, "VERBOSE:0.1"); err != ErrSpec {
+		t.Errorf("expected ErrSpec, got %v", err)
+	}
+	if _, err := NewSamplingHandler(next, "DEBUG:2"); err != ErrProbability {
+		t.Errorf("expected ErrProbability, got %v", err)
+	}
+}
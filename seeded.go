@@ -0,0 +1,48 @@
+package discreteprobability
+
+// splitmix is a SplitMix64 random source. It's cheap to create,
+// so a draw can be made from a seed without keeping any state.
+type splitmix struct {
+	state uint64
+}
+
+func (s *splitmix) Seed(seed int64) {
+	s.state = uint64(seed)
+}
+
+func (s *splitmix) Uint64() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func (s *splitmix) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// RandomSeeded returns the value drawn with the supplied seed only, without touching
+// the random stream of the generator. The same seed always gives the same value,
+// e.g. a hash of an order ID for a reproducible decision per order.
+func (g *Generator) RandomSeeded(seed uint64) interface{} {
+	i := g.pick(&splitmix{state: seed})
+	g.observe(i, false)
+	return g.values[i].Interface()
+}
+
+// RandomFromUniform returns the value for the uniform u in [0, 1) supplied by the caller,
+// mapped through the cumulative weights in ascending order of the values, as in InverseCDF.
+// The same u always gives the same value, e.g. for common random numbers across the runs
+// of a simulation or with the uniforms of another random number generator, and a small change
+// of the weights only moves the values near the changed ones. Values of kinds which can't be
+// compared keep the order of the generator.
+// It will return ErrProbability if u is out of range [0, 1)
+func (g *Generator) RandomFromUniform(u float64) (interface{}, error) {
+	if !(u >= 0 && u < 1) {
+		return nil, ErrProbability
+	}
+	i := g.uniformIndex(u)
+	g.observe(i, false)
+	return g.values[i].Interface(), nil
+}
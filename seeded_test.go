@@ -0,0 +1,53 @@
+package discreteprobability
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRandomSeeded(t *testing.T) {
+	g := generateInt(t, time.Now().Unix(), sliceLen)
+	occurrence := map[int]float64{}
+	for seed := uint64(0); seed < repeats; seed++ {
+		v := g.RandomSeeded(seed)
+		if v != g.RandomSeeded(seed) {
+			t.Errorf("seed %v got different values", seed)
+			t.FailNow()
+		}
+		occurrence[v.(int)]++
+	}
+
+	p := float64(repeats) / sliceLen
+	for v, count := range occurrence {
+		if d := p * 5 / 100; count > p+d || count < p-d {
+			t.Errorf("incorrect distribution value %v, expected %f, got %f", v, p, count)
+		}
+	}
+}
+
+func TestRandomFromUniform(t *testing.T) {
+	g, _ := New([]string{"a", "b"}, []float64{0.25, 0.75})
+	for _, test := range []struct {
+		u     float64
+		value string
+	}{{0, "a"}, {0.2, "a"}, {0.3, "b"}, {0.99, "b"}} {
+		if v, err := g.RandomFromUniform(test.u); err != nil || v != test.value {
+			t.Errorf("RandomFromUniform of %v expected %v, got %v %v", test.u, test.value, v, err)
+		}
+	}
+	// the uniforms are mapped in the order of the values, not of the weights
+	n, _ := New([]int{2, 1}, []float64{0.25, 0.75})
+	for u, value := range map[float64]int{0.5: 1, 0.8: 2} {
+		if v, _ := n.RandomFromUniform(u); v != value {
+			t.Errorf("RandomFromUniform of %v expected %v, got %v", u, value, v)
+		}
+		if v, _ := n.InverseCDF(u); v != value {
+			t.Errorf("InverseCDF of %v expected %v, got %v", u, value, v)
+		}
+	}
+	for _, u := range []float64{-0.1, 1} {
+		if _, err := g.RandomFromUniform(u); err != ErrProbability {
+			t.Errorf("RandomFromUniform of %v expected ErrProbability, got %v", u, err)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Package selection is the selection operators of genetic algorithms over fitness scores:
+// roulette-wheel, stochastic universal sampling and tournament selection. The roulette-wheel
+// and stochastic universal sampling draw from a discreteprobability.Generator.
+// Example usage:
+//
+//		var s selection.Selector = selection.NewTournament(3)
+//		parents, err := s.Select(fitness, len(population))
+//		if err != nil {
+//			panic(err) // Error handlers
+//		}
+//		for _, i := range parents {
+//			mate(population[i])
+//		}
+package selection
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+// Selector selects n indexes of the fitness scores, with repetition. A higher fitness is better.
+type Selector interface {
+	Select(fitness []float64, n int) ([]int, error)
+}
+
+// generator returns a Generator of the indexes of the fitness scores with probabilities
+// proportional to them, seeded from rnd.
+func generator(fitness []float64, rnd *rand.Rand) (*discreteprobability.Generator, error) {
+	indexes := make([]int, len(fitness))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	g, err := discreteprobability.NewNormalized(indexes, fitness)
+	if err != nil {
+		return nil, err
+	}
+	g.SetSeed(rnd.Int63())
+	return g, nil
+}
+
+func ints(values []interface{}) []int {
+	selected := make([]int, len(values))
+	for i, v := range values {
+		selected[i] = v.(int)
+	}
+	return selected
+}
+
+// Roulette is the roulette-wheel selection: every index is drawn independently with the
+// probability proportional to its fitness.
+type Roulette struct {
+	rnd *rand.Rand
+}
+
+// NewRoulette returns a new Roulette.
+func NewRoulette() *Roulette {
+	return &Roulette{rnd: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (r *Roulette) SetSeed(s int64) {
+	r.rnd = rand.New(rand.NewSource(s))
+}
+
+// Select returns n indexes drawn with the fitness scores.
+// It will return ErrNegativeWeight if any fitness is negative, or ErrWeightSum if none is positive
+func (r *Roulette) Select(fitness []float64, n int) ([]int, error) {
+	g, err := generator(fitness, r.rnd)
+	if err != nil {
+		return nil, err
+	}
+	return ints(g.SampleN(n)), nil
+}
+
+// Universal is the stochastic universal sampling: n evenly spaced pointers with a single random
+// offset over the fitness scores, so every index is selected within one of its expected count.
+type Universal struct {
+	rnd *rand.Rand
+}
+
+// NewUniversal returns a new Universal.
+func NewUniversal() *Universal {
+	return &Universal{rnd: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (u *Universal) SetSeed(s int64) {
+	u.rnd = rand.New(rand.NewSource(s))
+}
+
+// Select returns n indexes selected with the fitness scores, shuffled.
+// It will return ErrNegativeWeight if any fitness is negative, or ErrWeightSum if none is positive
+func (u *Universal) Select(fitness []float64, n int) ([]int, error) {
+	g, err := generator(fitness, u.rnd)
+	if err != nil {
+		return nil, err
+	}
+	selected := ints(g.SystematicSample(n))
+	u.rnd.Shuffle(len(selected), func(i, j int) {
+		selected[i], selected[j] = selected[j], selected[i]
+	})
+	return selected, nil
+}
+
+// Tournament is the tournament selection: every index is the fittest of Size indexes drawn
+// uniformly. It only depends on the order of the fitness scores, so they can be negative.
+type Tournament struct {
+	// Size is the number of indexes in a tournament, a larger size selects more strongly
+	Size int
+
+	rnd *rand.Rand
+}
+
+// NewTournament returns a new Tournament of the size.
+func NewTournament(size int) *Tournament {
+	return &Tournament{Size: size, rnd: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (t *Tournament) SetSeed(s int64) {
+	t.rnd = rand.New(rand.NewSource(s))
+}
+
+// Select returns the winners of n tournaments.
+// It will return ErrEmptyInput if there are no fitness scores
+func (t *Tournament) Select(fitness []float64, n int) ([]int, error) {
+	if len(fitness) == 0 {
+		return nil, discreteprobability.ErrEmptyInput
+	}
+	if n <= 0 {
+		return nil, nil
+	}
+	size := t.Size
+	if size < 1 {
+		size = 1
+	}
+
+	selected := make([]int, n)
+	for i := range selected {
+		best := t.rnd.Intn(len(fitness))
+		for j := 1; j < size; j++ {
+			if c := t.rnd.Intn(len(fitness)); fitness[c] > fitness[best] {
+				best = c
+			}
+		}
+		selected[i] = best
+	}
+	return selected, nil
+}
@@ -0,0 +1,86 @@
+package selection
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+const repeats = 100000
+
+var fitness = []float64{1, 0, 3, 6}
+
+func TestRoulette(t *testing.T) {
+	r := NewRoulette()
+	r.SetSeed(time.Now().Unix())
+	selected, err := r.Select(fitness, repeats)
+	if err != nil {
+		t.Errorf("Select error %v", err)
+		t.FailNow()
+	}
+	counts := make([]float64, len(fitness))
+	for _, i := range selected {
+		counts[i]++
+	}
+	for i, f := range fitness {
+		p := f / 10 * repeats
+		if d := p * 3 / 100; counts[i] > p+d || counts[i] < p-d {
+			t.Errorf("incorrect distribution index %v, expected %f, got %f", i, p, counts[i])
+		}
+	}
+
+	if _, err := r.Select([]float64{1, -1}, 1); err != discreteprobability.ErrNegativeWeight {
+		t.Errorf("expected ErrNegativeWeight, got %v", err)
+	}
+	if _, err := r.Select([]float64{0, 0}, 1); err != discreteprobability.ErrWeightSum {
+		t.Errorf("expected ErrWeightSum, got %v", err)
+	}
+}
+
+func TestUniversal(t *testing.T) {
+	u := NewUniversal()
+	u.SetSeed(time.Now().Unix())
+	for round := 0; round < 100; round++ {
+		selected, err := u.Select(fitness, 15)
+		if err != nil {
+			t.Errorf("Select error %v", err)
+			t.FailNow()
+		}
+		counts := make([]float64, len(fitness))
+		for _, i := range selected {
+			counts[i]++
+		}
+		for i, f := range fitness {
+			if expected := f / 10 * 15; math.Abs(counts[i]-expected) >= 1 {
+				t.Errorf("index %v selected %v times, expected %v", i, counts[i], expected)
+				t.FailNow()
+			}
+		}
+	}
+}
+
+func TestTournament(t *testing.T) {
+	tournament := NewTournament(len(fitness) * 10)
+	tournament.SetSeed(time.Now().Unix())
+	selected, err := tournament.Select([]float64{-5, -1, -3}, 100)
+	if err != nil {
+		t.Errorf("Select error %v", err)
+		t.FailNow()
+	}
+	best := 0
+	for _, i := range selected {
+		if i == 1 {
+			best++
+		}
+	}
+	if best < 95 {
+		t.Errorf("expected the fittest index to win most large tournaments, got %v of 100", best)
+	}
+
+	var s Selector = tournament
+	if _, err := s.Select(nil, 1); err != discreteprobability.ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+}
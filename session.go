@@ -0,0 +1,44 @@
+package discreteprobability
+
+import "reflect"
+
+// SessionSampler draws from a Generator without returning the same value twice within
+// a session, e.g. not showing the same recommendation twice on a page. The values which
+// have been returned are excluded, and the rest of the values are renormalized.
+type SessionSampler struct {
+	g      *Generator
+	picked []bool
+}
+
+// Session returns a new SessionSampler over the values of g.
+func (g *Generator) Session() *SessionSampler {
+	return &SessionSampler{
+		g:      g,
+		picked: make([]bool, g.size),
+	}
+}
+
+// Random returns a value which has not been returned in the session.
+// It will return ErrNotEnough if all the values with positive weights have been returned.
+func (s *SessionSampler) Random() (interface{}, error) {
+	i := s.g.drawWithout(s.picked, func(int) bool { return true })
+	if i < 0 {
+		return nil, ErrNotEnough
+	}
+
+	v := s.g.values[i].Interface()
+	// a value which is in the generator more than once is excluded at all its indexes
+	for j, value := range s.g.values {
+		if !s.picked[j] && reflect.DeepEqual(value.Interface(), v) {
+			s.picked[j] = true
+		}
+	}
+	return v, nil
+}
+
+// Reset starts a new session, in which all the values can be returned again.
+func (s *SessionSampler) Reset() {
+	for i := range s.picked {
+		s.picked[i] = false
+	}
+}
@@ -0,0 +1,37 @@
+package discreteprobability
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionSampler(t *testing.T) {
+	g, err := New([]string{"a", "b", "a", "c", "d"}, []float64{0.1, 0.2, 0.3, 0.4, 0})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+	g.SetSeed(time.Now().Unix())
+	s := g.Session()
+
+	for round := 0; round < 2; round++ {
+		seen := map[interface{}]bool{}
+		for i := 0; i < 3; i++ {
+			v, err := s.Random()
+			if err != nil {
+				t.Errorf("Random error %v", err)
+				t.FailNow()
+			}
+			if seen[v] {
+				t.Errorf("value %v returned twice", v)
+				t.FailNow()
+			}
+			seen[v] = true
+		}
+		if _, err := s.Random(); err != ErrNotEnough {
+			t.Errorf("expected ErrNotEnough, got %v", err)
+			t.FailNow()
+		}
+		s.Reset()
+	}
+}
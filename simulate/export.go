@@ -0,0 +1,100 @@
+package simulate
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Format is the output format of ExportHistogram
+type Format int
+
+const (
+	// CSV writes a header and a row of value, expected and empirical probability per value
+	CSV Format = iota
+	// Gnuplot writes whitespace separated columns, ready for `plot ... using 2:xtic(1)`
+	Gnuplot
+	// VegaLite writes a Vega-Lite spec of a grouped bar chart with the data inlined
+	VegaLite
+)
+
+// ErrFormat is returned when the format is unknown
+var ErrFormat = errors.New("unknown format")
+
+// ExportHistogram writes the configured and simulated probabilities of each value to w.
+func (s Summary) ExportHistogram(w io.Writer, format Format) error {
+	switch format {
+	case CSV:
+		return s.exportCSV(w)
+	case Gnuplot:
+		return s.exportGnuplot(w)
+	case VegaLite:
+		return s.exportVegaLite(w)
+	}
+	return ErrFormat
+}
+
+func (s Summary) exportCSV(w io.Writer) error {
+	c := csv.NewWriter(w)
+	if err := c.Write([]string{"value", "expected", "empirical"}); err != nil {
+		return err
+	}
+	for i, value := range s.Values {
+		err := c.Write([]string{
+			fmt.Sprint(value),
+			strconv.FormatFloat(s.Expected[i], 'g', -1, 64),
+			strconv.FormatFloat(s.Empirical[i], 'g', -1, 64),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	c.Flush()
+	return c.Error()
+}
+
+func (s Summary) exportGnuplot(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "# %d draws\n# value expected empirical\n", s.Draws); err != nil {
+		return err
+	}
+	for i, value := range s.Values {
+		if _, err := fmt.Fprintf(w, "%q %g %g\n", fmt.Sprint(value), s.Expected[i], s.Empirical[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s Summary) exportVegaLite(w io.Writer) error {
+	type datum struct {
+		Value       string  `json:"value"`
+		Source      string  `json:"source"`
+		Probability float64 `json:"probability"`
+	}
+	values := make([]datum, 0, 2*len(s.Values))
+	for i, value := range s.Values {
+		values = append(values,
+			datum{Value: fmt.Sprint(value), Source: "expected", Probability: s.Expected[i]},
+			datum{Value: fmt.Sprint(value), Source: "empirical", Probability: s.Empirical[i]},
+		)
+	}
+
+	spec := map[string]interface{}{
+		"$schema":     "https://vega.github.io/schema/vega-lite/v5.json",
+		"description": fmt.Sprintf("Expected and empirical probabilities of %d draws", s.Draws),
+		"data":        map[string]interface{}{"values": values},
+		"mark":        "bar",
+		"encoding": map[string]interface{}{
+			"x":       map[string]interface{}{"field": "value", "type": "nominal"},
+			"xOffset": map[string]interface{}{"field": "source"},
+			"y":       map[string]interface{}{"field": "probability", "type": "quantitative"},
+			"color":   map[string]interface{}{"field": "source"},
+		},
+	}
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(spec)
+}
@@ -0,0 +1,66 @@
+package simulate
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func summary() Summary {
+	return Summary{
+		Draws:     10,
+		Values:    []interface{}{"a", "b"},
+		Expected:  []float64{0.25, 0.75},
+		Counts:    []int{3, 7},
+		Empirical: []float64{0.3, 0.7},
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	var b bytes.Buffer
+	if err := summary().ExportHistogram(&b, CSV); err != nil {
+		t.Errorf("ExportHistogram error %v", err)
+		t.FailNow()
+	}
+	expected := "value,expected,empirical\na,0.25,0.3\nb,0.75,0.7\n"
+	if b.String() != expected {
+		t.Errorf("expected %q, got %q", expected, b.String())
+	}
+}
+
+func TestExportGnuplot(t *testing.T) {
+	var b bytes.Buffer
+	if err := summary().ExportHistogram(&b, Gnuplot); err != nil {
+		t.Errorf("ExportHistogram error %v", err)
+		t.FailNow()
+	}
+	if !strings.HasSuffix(b.String(), "\"a\" 0.25 0.3\n\"b\" 0.75 0.7\n") {
+		t.Errorf("unexpected output %q", b.String())
+	}
+}
+
+func TestExportVegaLite(t *testing.T) {
+	var b bytes.Buffer
+	if err := summary().ExportHistogram(&b, VegaLite); err != nil {
+		t.Errorf("ExportHistogram error %v", err)
+		t.FailNow()
+	}
+
+	var spec struct {
+		Data struct {
+			Values []map[string]interface{} `json:"values"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(b.Bytes(), &spec); err != nil {
+		t.Errorf("invalid json %v", err)
+		t.FailNow()
+	}
+	if len(spec.Data.Values) != 4 {
+		t.Errorf("expected 4 data points, got %v", spec.Data.Values)
+	}
+
+	if err := summary().ExportHistogram(&b, Format(-1)); err != ErrFormat {
+		t.Errorf("expected ErrFormat, got %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+// Package simulate draws from a discreteprobability.Generator many times and
+// summarizes the result, to sanity-check a configuration of weights.
+// Example usage:
+//
+//		summary := simulate.Run(generator, 100000)
+//		for i, value := range summary.Values {
+//			fmt.Println(value, summary.Expected[i], summary.Empirical[i])
+//		}
+package simulate
+
+import (
+	"math"
+	"reflect"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+// checkpoints is the number of points of the convergence curve made by Run
+const checkpoints = 100
+
+// Point is a point of the convergence curve, MaxError is the largest absolute
+// difference between the empirical and the expected probabilities after Draws draws.
+type Point struct {
+	Draws    int
+	MaxError float64
+}
+
+// Summary is the result of a simulation. Values, Expected, Counts and Empirical
+// have the same order.
+type Summary struct {
+	Draws     int
+	Values    []interface{}
+	Expected  []float64
+	Counts    []int
+	Empirical []float64
+	Curve     []Point
+}
+
+// Run draws n values from g and returns the summary, with a convergence curve of 100 points.
+func Run(g *discreteprobability.Generator, n int) Summary {
+	m := n / checkpoints
+	if m < 1 {
+		m = 1
+	}
+	return Stream(g, n, m, nil)
+}
+
+// Stream draws n values from g and adds a point to the convergence curve every m draws.
+// If fn is not nil, it's called with the summary so far at every point.
+func Stream(g *discreteprobability.Generator, n int, m int, fn func(Summary)) Summary {
+	values, expected := g.Distribution()
+	s := Summary{
+		Values:    values,
+		Expected:  expected,
+		Counts:    make([]int, len(values)),
+		Empirical: make([]float64, len(values)),
+	}
+	index := newIndex(values)
+
+	for s.Draws < n {
+		if i := index.find(g.RandomInterface()); i >= 0 {
+			s.Counts[i]++
+		}
+		s.Draws++
+
+		if m > 0 && s.Draws%m == 0 || s.Draws == n {
+			s.update()
+			if fn != nil {
+				fn(s)
+			}
+		}
+	}
+	return s
+}
+
+// update computes the empirical probabilities and appends a point to the curve.
+func (s *Summary) update() {
+	maxError := float64(0)
+	for i, count := range s.Counts {
+		s.Empirical[i] = float64(count) / float64(s.Draws)
+		maxError = math.Max(maxError, math.Abs(s.Empirical[i]-s.Expected[i]))
+	}
+	if len(s.Curve) == 0 || s.Curve[len(s.Curve)-1].Draws != s.Draws {
+		s.Curve = append(s.Curve, Point{Draws: s.Draws, MaxError: maxError})
+	}
+}
+
+// index finds the position of a drawn value, with a map for comparable values.
+type index struct {
+	values []interface{}
+	lookup map[interface{}]int
+}
+
+func newIndex(values []interface{}) *index {
+	x := &index{values: values, lookup: make(map[interface{}]int, len(values))}
+	for i, v := range values {
+		if v == nil || !reflect.TypeOf(v).Comparable() {
+			x.lookup = nil
+			break
+		}
+		x.lookup[v] = i
+	}
+	return x
+}
+
+func (x *index) find(v interface{}) int {
+	if x.lookup != nil {
+		if i, ok := x.lookup[v]; ok {
+			return i
+		}
+		return -1
+	}
+	for i, value := range x.values {
+		if reflect.DeepEqual(value, v) {
+			return i
+		}
+	}
+	return -1
+}
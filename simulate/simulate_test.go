@@ -0,0 +1,54 @@
+package simulate
+
+import (
+	"testing"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+const repeats = 100000
+
+func generate(t *testing.T) *discreteprobability.Generator {
+	g, err := discreteprobability.New([]string{"a", "b", "c"}, []float64{0.2, 0.5, 0.3})
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	g.SetSeed(1)
+	return g
+}
+
+func TestRun(t *testing.T) {
+	s := Run(generate(t), repeats)
+	if s.Draws != repeats || len(s.Curve) != checkpoints {
+		t.Errorf("unexpected summary of %v draws and %v points", s.Draws, len(s.Curve))
+		t.FailNow()
+	}
+
+	total := 0
+	for i, count := range s.Counts {
+		total += count
+		if d := s.Expected[i] * 3 / 100; s.Empirical[i] > s.Expected[i]+d || s.Empirical[i] < s.Expected[i]-d {
+			t.Errorf("incorrect probability of %v, expected %f, got %f", s.Values[i], s.Expected[i], s.Empirical[i])
+		}
+	}
+	if total != repeats {
+		t.Errorf("expected %v draws counted, got %v", repeats, total)
+	}
+	if last := s.Curve[len(s.Curve)-1]; last.Draws != repeats || last.MaxError > s.Curve[0].MaxError {
+		t.Errorf("curve not converged %v %v", s.Curve[0], last)
+	}
+}
+
+func TestStream(t *testing.T) {
+	calls := 0
+	s := Stream(generate(t), 1050, 100, func(s Summary) {
+		calls++
+		if s.Draws != calls*100 && s.Draws != 1050 {
+			t.Errorf("unexpected callback at %v draws", s.Draws)
+		}
+	})
+	if calls != 11 || len(s.Curve) != 11 {
+		t.Errorf("expected 11 callbacks, got %v", calls)
+	}
+}
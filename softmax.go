@@ -0,0 +1,31 @@
+package discreteprobability
+
+import "math"
+
+// NewSoftmax returns a new Generator whose weights are the softmax of the scores,
+// exp(score/temperature) normalized. A higher temperature flattens the distribution.
+// The max score is subtracted before exponentiation, so large scores don't overflow.
+// It will return error if values and scores have different length or the temperature is not positive
+func NewSoftmax(v interface{}, scores []float64, temperature float64) (*Generator, error) {
+	if !(temperature > 0) {
+		return nil, ErrTemperature
+	}
+	if len(scores) == 0 {
+		return New(v, scores)
+	}
+
+	max := math.Inf(-1)
+	for _, score := range scores {
+		max = math.Max(max, score)
+	}
+
+	weights := make([]float64, len(scores))
+	for i, score := range scores {
+		weights[i] = math.Exp((score - max) / temperature)
+	}
+	weights, err := normalize(weights)
+	if err != nil {
+		return nil, err
+	}
+	return New(v, weights)
+}
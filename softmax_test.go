@@ -0,0 +1,33 @@
+package discreteprobability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewSoftmax(t *testing.T) {
+	g, err := NewSoftmax([]string{"a", "b"}, []float64{1000, 1000 + math.Log(3)}, 1)
+	if err != nil {
+		t.Errorf("NewSoftmax error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(g, "a", 0.25) || !weightEqual(g, "b", 0.75) {
+		t.Errorf("unexpected generator %v", g)
+	}
+
+	g, err = NewSoftmax([]string{"a", "b"}, []float64{0, math.Log(3)}, 1e9)
+	if err != nil {
+		t.Errorf("NewSoftmax error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(g, "a", 0.5) || !weightEqual(g, "b", 0.5) {
+		t.Errorf("expected uniform weights with high temperature, got %v", g)
+	}
+
+	if _, err := NewSoftmax([]string{"a"}, []float64{1}, 0); err != ErrTemperature {
+		t.Errorf("expected ErrTemperature, got %v", err)
+	}
+	if _, err := NewSoftmax([]string{"a"}, []float64{1, 2}, 1); err != ErrLength {
+		t.Errorf("expected ErrLength, got %v", err)
+	}
+}
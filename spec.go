@@ -0,0 +1,165 @@
+package discreteprobability
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Spec is the configuration of a string or int generator, which can be stored in a
+// single database column with the compact "value:weight,value:weight" encoding.
+type Spec struct {
+	// Values is either []int or []string
+	Values  interface{}
+	Weights []float64
+}
+
+// Spec returns the spec of a string or int generator, from the most probable value to the least.
+// It will return ErrType for other types of values.
+func (g *Generator) Spec() (Spec, error) {
+	weights := make([]float64, 0, g.size)
+	var ints []int
+	var texts []string
+	for i := g.size - 1; i >= 0; i-- {
+		switch v := g.values[i]; v.Kind() {
+		case reflect.String:
+			texts = append(texts, v.String())
+		case reflect.Int:
+			ints = append(ints, int(v.Int()))
+		default:
+			return Spec{}, ErrType
+		}
+		weights = append(weights, g.probability(i))
+	}
+
+	if ints != nil {
+		return Spec{Values: ints, Weights: weights}, nil
+	}
+	if texts == nil {
+		texts = []string{}
+	}
+	return Spec{Values: texts, Weights: weights}, nil
+}
+
+// Generator returns a new Generator of the spec.
+func (s Spec) Generator() (*Generator, error) {
+	return New(s.Values, s.Weights)
+}
+
+// MarshalText encodes the spec as "value:weight,value:weight".
+// It will return ErrType if Values is not []int or []string, ErrLength if the length
+// of values and weights are different, or ErrSpec if a string value contains a comma.
+func (s Spec) MarshalText() ([]byte, error) {
+	var values []string
+	switch v := s.Values.(type) {
+	case []string:
+		for _, value := range v {
+			if strings.Contains(value, ",") {
+				return nil, ErrSpec
+			}
+		}
+		values = v
+	case []int:
+		values = make([]string, len(v))
+		for i, value := range v {
+			values[i] = strconv.Itoa(value)
+		}
+	default:
+		return nil, ErrType
+	}
+	if len(values) != len(s.Weights) {
+		return nil, ErrLength
+	}
+
+	var b strings.Builder
+	for i, value := range values {
+		if i != 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(value)
+		b.WriteByte(':')
+		b.WriteString(strconv.FormatFloat(s.Weights[i], 'g', -1, 64))
+	}
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText decodes a "value:weight,value:weight" spec. If Values is already
+// []int or []string the decoded values keep the type, otherwise the values are
+// ints when all of them are integers and strings if not.
+func (s *Spec) UnmarshalText(text []byte) error {
+	values, weights, err := parseSpec(string(text))
+	if err != nil {
+		return err
+	}
+
+	_, isString := s.Values.([]string)
+	_, isInt := s.Values.([]int)
+	if !isString {
+		ints := make([]int, len(values))
+		for i, value := range values {
+			if ints[i], err = strconv.Atoi(value); err != nil {
+				break
+			}
+		}
+		if err == nil {
+			s.Values, s.Weights = ints, weights
+			return nil
+		}
+		if isInt {
+			return ErrSpec
+		}
+	}
+
+	s.Values, s.Weights = values, weights
+	return nil
+}
+
+// Value implements driver.Valuer and stores the spec as text.
+func (s Spec) Value() (driver.Value, error) {
+	text, err := s.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
+
+// Scan implements sql.Scanner and reads the spec from a text column.
+// A NULL column leaves an empty spec.
+func (s *Spec) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*s = Spec{}
+		return nil
+	case string:
+		return s.UnmarshalText([]byte(v))
+	case []byte:
+		return s.UnmarshalText(v)
+	}
+	return ErrType
+}
+
+// parseSpec splits a "value:weight,value:weight" spec into values and weights.
+func parseSpec(spec string) ([]string, []float64, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil, ErrSpec
+	}
+
+	pairs := strings.Split(spec, ",")
+	values := make([]string, len(pairs))
+	weights := make([]float64, len(pairs))
+	for i, pair := range pairs {
+		sep := strings.LastIndex(pair, ":")
+		if sep < 0 {
+			return nil, nil, ErrSpec
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(pair[sep+1:]), 64)
+		if err != nil {
+			return nil, nil, ErrSpec
+		}
+		values[i] = strings.TrimSpace(pair[:sep])
+		weights[i] = weight
+	}
+	return values, weights, nil
+}
@@ -0,0 +1,63 @@
+package discreteprobability
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+var (
+	_ driver.Valuer = Spec{}
+	_ sql.Scanner   = (*Spec)(nil)
+)
+
+func TestSpecValue(t *testing.T) {
+	g, _ := New([]int{1, 2}, []float64{0.25, 0.75})
+	s, err := g.Spec()
+	if err != nil {
+		t.Errorf("Spec error %v", err)
+		t.FailNow()
+	}
+
+	v, err := s.Value()
+	if err != nil {
+		t.Errorf("Value error %v", err)
+		t.FailNow()
+	}
+	if v != "2:0.75,1:0.25" {
+		t.Errorf("unexpected value %v", v)
+	}
+
+	if _, err := (Spec{Values: []float64{1}, Weights: []float64{1}}).Value(); err != ErrType {
+		t.Errorf("expected ErrType, got %v", err)
+	}
+}
+
+func TestSpecScan(t *testing.T) {
+	var s Spec
+	if err := s.Scan([]byte("a:0.5,b:0.5")); err != nil {
+		t.Errorf("Scan error %v", err)
+		t.FailNow()
+	}
+	g, err := s.Generator()
+	if err != nil {
+		t.Errorf("Generator error %v", err)
+		t.FailNow()
+	}
+	if !weightEqual(g, "a", 0.5) || !weightEqual(g, "b", 0.5) {
+		t.Errorf("unexpected generator %v", g)
+	}
+
+	if err := s.Scan("1:1"); err != nil {
+		t.Errorf("Scan error %v", err)
+	}
+	if _, ok := s.Values.([]string); !ok {
+		t.Errorf("expected string values to be kept, got %v", s.Values)
+	}
+	if err := s.Scan(nil); err != nil || s.Values != nil {
+		t.Errorf("expected empty spec, got %v %v", s, err)
+	}
+	if err := s.Scan(1); err != ErrType {
+		t.Errorf("expected ErrType, got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package discreteprobability
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// StickyPicker assigns each key a value drawn with the weights on first sight and keeps
+// returning it until it expires, e.g. a canary router which keeps a user on one version.
+// It's safe for concurrent use, as long as the Generator is not used elsewhere at the same time.
+type StickyPicker struct {
+	mu       sync.Mutex
+	g        *Generator
+	ttl      time.Duration
+	capacity int
+	entries  map[string]*list.Element
+	lru      *list.List
+	now      func() time.Time
+}
+
+type stickyEntry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+// Sticky returns a new StickyPicker over the values of g. A value is kept for ttl after
+// the key is first seen, or forever if ttl is not positive. At most capacity keys are kept,
+// evicting the least recently used one, or any number if capacity is not positive.
+func (g *Generator) Sticky(ttl time.Duration, capacity int) *StickyPicker {
+	return &StickyPicker{
+		g:        g,
+		ttl:      ttl,
+		capacity: capacity,
+		entries:  map[string]*list.Element{},
+		lru:      list.New(),
+		now:      time.Now,
+	}
+}
+
+// Pick returns the value of the key, which is drawn again once it has expired or been evicted.
+func (s *StickyPicker) Pick(key string) interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if e, ok := s.entries[key]; ok {
+		entry := e.Value.(*stickyEntry)
+		if s.ttl <= 0 || now.Before(entry.expires) {
+			s.lru.MoveToFront(e)
+			return entry.value
+		}
+		s.lru.Remove(e)
+		delete(s.entries, key)
+	}
+
+	entry := &stickyEntry{key: key, value: s.g.RandomInterface(), expires: now.Add(s.ttl)}
+	s.entries[key] = s.lru.PushFront(entry)
+	if s.capacity > 0 && s.lru.Len() > s.capacity {
+		oldest := s.lru.Back()
+		s.lru.Remove(oldest)
+		delete(s.entries, oldest.Value.(*stickyEntry).key)
+	}
+	return entry.value
+}
+
+// Forget removes the value of the key, so it's drawn again on the next Pick.
+func (s *StickyPicker) Forget(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[key]; ok {
+		s.lru.Remove(e)
+		delete(s.entries, key)
+	}
+}
+
+// Len returns the number of keys kept.
+func (s *StickyPicker) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lru.Len()
+}
@@ -0,0 +1,67 @@
+package discreteprobability
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestStickyPicker(t *testing.T) {
+	g, _ := New([]string{"stable", "canary"}, []float64{0.9, 0.1})
+	g.SetSeed(time.Now().Unix())
+	s := g.Sticky(time.Hour, 0)
+	now := time.Now()
+	s.now = func() time.Time { return now }
+
+	picks := map[string]interface{}{}
+	count := 0
+	for i := 0; i < repeats/10; i++ {
+		key := strconv.Itoa(i)
+		picks[key] = s.Pick(key)
+		if picks[key] == "canary" {
+			count++
+		}
+	}
+	p := float64(repeats/10) * 0.1
+	if d := p * 20 / 100; float64(count) > p+d || float64(count) < p-d {
+		t.Errorf("incorrect distribution, expected %f, got %d", p, count)
+	}
+	for key, v := range picks {
+		if s.Pick(key) != v {
+			t.Errorf("key %v got a different value", key)
+			t.FailNow()
+		}
+	}
+
+	// after the ttl the values are drawn again, so some of them change
+	now = now.Add(time.Hour)
+	changed := 0
+	for key, v := range picks {
+		if s.Pick(key) != v {
+			changed++
+		}
+	}
+	if changed == 0 {
+		t.Errorf("expected values drawn again after the ttl")
+	}
+}
+
+func TestStickyPickerCapacity(t *testing.T) {
+	g, _ := New([]int{1, 2}, []float64{0.5, 0.5})
+	s := g.Sticky(0, 2)
+	s.Pick("a")
+	s.Pick("b")
+	s.Pick("a")
+	s.Pick("c")
+	if s.Len() != 2 {
+		t.Errorf("expected 2 keys, got %v", s.Len())
+	}
+	if _, ok := s.entries["b"]; ok {
+		t.Errorf("expected the least recently used key evicted")
+	}
+
+	s.Forget("a")
+	if s.Len() != 1 {
+		t.Errorf("expected 1 key, got %v", s.Len())
+	}
+}
@@ -0,0 +1,57 @@
+package discreteprobability
+
+import "sort"
+
+// Stratified picks a group by its weight first, then draws a value
+// from the Generator of that group.
+type Stratified struct {
+	groups  *Generator
+	members map[string]*Generator
+}
+
+// NewStratified returns a new Stratified sampler. It will return error if groups and groupWeights
+// don't have the same keys or the group weights are invalid.
+func NewStratified(groups map[string]*Generator, groupWeights map[string]float64) (*Stratified, error) {
+	if len(groups) != len(groupWeights) {
+		return nil, ErrGroup
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		if _, ok := groupWeights[name]; !ok {
+			return nil, ErrGroup
+		}
+		names = append(names, name)
+	}
+	// map iteration order is random, sort the names so a seeded sampler is reproducible
+	sort.Strings(names)
+
+	weights := make([]float64, len(names))
+	members := make(map[string]*Generator, len(names))
+	for i, name := range names {
+		weights[i] = groupWeights[name]
+		members[name] = groups[name]
+	}
+
+	g, err := New(names, weights)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Stratified{
+		groups:  g,
+		members: members,
+	}, nil
+}
+
+// SetSeed is to set a custom random seed for picking the group.
+// The generators of each group keep their own seeds.
+func (s *Stratified) SetSeed(seed int64) {
+	s.groups.SetSeed(seed)
+}
+
+// Random returns the picked group and the value drawn from the generator of that group.
+func (s *Stratified) Random() (string, interface{}) {
+	group := s.groups.RandomString()
+	return group, s.members[group].random().Interface()
+}
@@ -0,0 +1,59 @@
+package discreteprobability
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStratified(t *testing.T) {
+	s, err := NewStratified(map[string]*Generator{
+		"int":    generateInt(t, time.Now().Unix(), sliceLen),
+		"string": generateString(t, time.Now().Unix(), sliceLen),
+	}, map[string]float64{
+		"int":    0.3,
+		"string": 0.7,
+	})
+	if err != nil {
+		t.Errorf("NewStratified error %v", err)
+		t.FailNow()
+	}
+	s.SetSeed(time.Now().Unix())
+
+	count := 0
+	for i := 0; i < repeats; i++ {
+		group, value := s.Random()
+		switch group {
+		case "int":
+			if _, ok := value.(int); !ok {
+				t.Errorf("group %v got value %v", group, value)
+				t.FailNow()
+			}
+			count++
+		case "string":
+			if _, ok := value.(string); !ok {
+				t.Errorf("group %v got value %v", group, value)
+				t.FailNow()
+			}
+		default:
+			t.Errorf("unexpected group %v", group)
+			t.FailNow()
+		}
+	}
+
+	p := float64(repeats) * 0.3
+	if d := p * 3 / 100; float64(count) > p+d || float64(count) < p-d {
+		t.Errorf("incorrect group distribution, expected %f, got %d", p, count)
+		t.FailNow()
+	}
+}
+
+func TestStratifiedGroupMismatch(t *testing.T) {
+	_, err := NewStratified(map[string]*Generator{
+		"a": generateInt(t, 1, sliceLen),
+	}, map[string]float64{
+		"b": 1,
+	})
+	if err != ErrGroup {
+		t.Errorf("expected ErrGroup, got %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package discreteprobability
+
+import (
+	"reflect"
+)
+
+// Subset returns a new Generator over only the listed values of g, with their weights
+// renormalized. The values are shared with g instead of copied. A value which appears
+// more than once in g keeps all its weight. The random stream of the new Generator is seeded from g.
+// It will return ErrValue if any of the values is not in g, or ErrWeightSum if none of
+// the listed values has a positive weight
+func (g *Generator) Subset(values ...interface{}) (*Generator, error) {
+	buf := g.getPicked(g.size)
+	defer g.putPicked(buf)
+	picked := *buf
+	for _, v := range values {
+		found := false
+		for i, value := range g.values {
+			if reflect.DeepEqual(value.Interface(), v) {
+				picked[i] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, ErrValue
+		}
+	}
+
+	subset := make([]reflect.Value, 0, len(values))
+	weights := make([]float64, 0, len(values))
+	for i, value := range g.values {
+		if picked[i] {
+			subset = append(subset, value)
+			weights = append(weights, g.probability(i))
+		}
+	}
+	weights, err := normalize(weights)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := newGenerator(subset, weights)
+	if err != nil {
+		return nil, err
+	}
+	s.SetSeed(g.source.Int63())
+	return s, nil
+}
@@ -0,0 +1,70 @@
+package discreteprobability
+
+import (
+	"testing"
+)
+
+func TestSubset(t *testing.T) {
+	g, err := New([]string{"a", "b", "c", "d"}, []float64{0.1, 0.2, 0.3, 0.4})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+
+	s, err := g.Subset("a", "d", "a")
+	if err != nil {
+		t.Errorf("Subset error %v", err)
+		t.FailNow()
+	}
+	if s.size != 2 || !weightEqual(s, "a", 0.2) || !weightEqual(s, "d", 0.8) {
+		t.Errorf("unexpected subset %v", s)
+	}
+	for i := 0; i < 100; i++ {
+		if v := s.RandomString(); v != "a" && v != "d" {
+			t.Errorf("unexpected value %v", v)
+			t.FailNow()
+		}
+	}
+	if g.size != 4 || !weightEqual(g, "a", 0.1) {
+		t.Errorf("the generator was modified %v", g)
+	}
+
+	if _, err := g.Subset("a", "e"); err != ErrValue {
+		t.Errorf("expected ErrValue, got %v", err)
+	}
+}
+
+func TestSubsetZeroWeight(t *testing.T) {
+	g, err := New([]int{1, 2}, []float64{0, 1})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+	if _, err := g.Subset(1); err != ErrWeightSum {
+		t.Errorf("expected ErrWeightSum, got %v", err)
+	}
+}
+
+func TestSubsetSeed(t *testing.T) {
+	g, _ := New([]string{"a", "b", "c", "d"}, []float64{0.1, 0.2, 0.3, 0.4})
+	g.SetSeed(42)
+	s, err := g.Subset("a", "b", "d")
+	if err != nil {
+		t.Errorf("Subset error %v", err)
+		t.FailNow()
+	}
+	// the subset takes its tick seed from g, not the package seed
+	if s.tickSeed == seed {
+		t.Errorf("expected the subset to be seeded by the generator")
+		t.FailNow()
+	}
+
+	g.SetSeed(42)
+	replay, _ := g.Subset("a", "b", "d")
+	for tick := uint64(0); tick < 100; tick++ {
+		if a, b := s.RandomAtTick(tick), replay.RandomAtTick(tick); a != b {
+			t.Errorf("tick %d drew %v and %v with the same seed", tick, a, b)
+			t.FailNow()
+		}
+	}
+}
@@ -0,0 +1,215 @@
+// Package synth generates synthetic rows of data, whose columns are drawn from generators.
+// A column can depend on another one through a conditional table, e.g. the plan given the country.
+// Example usage:
+//
+//		plans, _ := discreteprobability.NewConditional(map[string]*discreteprobability.Generator{
+//			"US": gPlanUS,
+//			"DE": gPlanDE,
+//		})
+//		d := synth.New(synth.Schema{"country": gCountry, "device": gDevice}).Given("plan", "country", plans)
+//		if err := d.WriteCSV(os.Stdout, 1000); err != nil {
+//			panic(err) // Error handlers
+//		}
+//
+//		The columns are written in the order of their names: country, device, plan.
+package synth
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+// ErrDuplicate is returned when a column is defined more than once
+var ErrDuplicate = errors.New("column defined more than once")
+
+// ErrCycle is returned when the conditions of the columns form a cycle
+var ErrCycle = errors.New("columns depend on each other")
+
+// Schema is the independent columns with their generators by name.
+type Schema map[string]*discreteprobability.Generator
+
+// Row is a generated row, the values by column name.
+type Row map[string]interface{}
+
+// given is a column drawn from a conditional table with the value of the column it depends on.
+type given struct {
+	on    string
+	table *discreteprobability.Conditional
+}
+
+// Dataset generates the rows of a Schema and its dependent columns.
+type Dataset struct {
+	schema Schema
+	given  map[string]given
+	rnd    *rand.Rand
+}
+
+// New returns a new Dataset of the columns of the schema.
+func New(schema Schema) *Dataset {
+	return &Dataset{
+		schema: schema,
+		given:  map[string]given{},
+		rnd:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Given adds a column drawn from the table given the value of the column on, and returns d.
+// The keys of the table should be of the type of the values of on.
+func (d *Dataset) Given(column, on string, table *discreteprobability.Conditional) *Dataset {
+	d.given[column] = given{on: on, table: table}
+	return d
+}
+
+// SetSeed is to set a custom random seed other than the time stamp. The generators and tables
+// of the columns are reseeded in the order of the column names, so the rows are reproducible.
+func (d *Dataset) SetSeed(s int64) {
+	d.rnd = rand.New(rand.NewSource(s))
+	for _, column := range d.Columns() {
+		if g, ok := d.schema[column]; ok {
+			g.SetSeed(d.rnd.Int63())
+		} else {
+			d.given[column].table.SetSeed(d.rnd.Int63())
+		}
+	}
+}
+
+// Columns returns the names of the columns, in order.
+func (d *Dataset) Columns() []string {
+	columns := make([]string, 0, len(d.schema)+len(d.given))
+	for column := range d.schema {
+		columns = append(columns, column)
+	}
+	for column := range d.given {
+		if _, ok := d.schema[column]; !ok {
+			columns = append(columns, column)
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// order returns the columns in an order in which every column comes after the one it depends on.
+// It will return ErrDuplicate if a column is both in the schema and given, discreteprobability.ErrColumn
+// if a column depends on an unknown one, or ErrCycle if the columns depend on each other.
+func (d *Dataset) order() ([]string, error) {
+	for column, g := range d.given {
+		if _, ok := d.schema[column]; ok {
+			return nil, ErrDuplicate
+		}
+		_, independent := d.schema[g.on]
+		if _, dependent := d.given[g.on]; !independent && !dependent {
+			return nil, discreteprobability.ErrColumn
+		}
+	}
+
+	order := make([]string, 0, len(d.schema)+len(d.given))
+	done := map[string]bool{}
+	for _, column := range d.Columns() {
+		// follow the chain of conditions up to an independent column
+		chain := []string{}
+		for c := column; ; c = d.given[c].on {
+			if done[c] {
+				break
+			}
+			if _, ok := d.given[c]; !ok {
+				done[c] = true
+				order = append(order, c)
+				break
+			}
+			if len(chain) > len(d.given) {
+				return nil, ErrCycle
+			}
+			chain = append(chain, c)
+		}
+		for i := len(chain) - 1; i >= 0; i-- {
+			done[chain[i]] = true
+			order = append(order, chain[i])
+		}
+	}
+	return order, nil
+}
+
+// GenerateRows returns n rows, or nil if n is not positive. It will return an error if the
+// columns are invalid, see order, or the error of a conditional table for a value of the
+// column it depends on.
+func (d *Dataset) GenerateRows(n int) ([]Row, error) {
+	var rows []Row
+	if n > 0 {
+		rows = make([]Row, 0, n)
+	}
+	err := d.eachRow(n, func(row Row) error {
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// eachRow generates n rows and calls f with each of them, so the rows don't have to be
+// kept in memory. It returns the first error of the columns, see GenerateRows, or of f.
+func (d *Dataset) eachRow(n int, f func(Row) error) error {
+	order, err := d.order()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		row := make(Row, len(order))
+		for _, column := range order {
+			if g, ok := d.schema[column]; ok {
+				row[column] = g.RandomInterface()
+				continue
+			}
+			given := d.given[column]
+			if row[column], err = given.table.RandomGiven(row[given.on]); err != nil {
+				return err
+			}
+		}
+		if err := f(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteCSV writes a header of the column names and n rows to w, with the values formatted by fmt.
+// The rows are written as they are generated.
+func (d *Dataset) WriteCSV(w io.Writer, n int) error {
+	if _, err := d.order(); err != nil {
+		return err
+	}
+	columns := d.Columns()
+	c := csv.NewWriter(w)
+	if err := c.Write(columns); err != nil {
+		return err
+	}
+	record := make([]string, len(columns))
+	err := d.eachRow(n, func(row Row) error {
+		for i, column := range columns {
+			record[i] = fmt.Sprint(row[column])
+		}
+		return c.Write(record)
+	})
+	if err != nil {
+		return err
+	}
+	c.Flush()
+	return c.Error()
+}
+
+// WriteJSON writes n rows to w as JSON lines, an object per row, as they are generated.
+func (d *Dataset) WriteJSON(w io.Writer, n int) error {
+	e := json.NewEncoder(w)
+	return d.eachRow(n, func(row Row) error {
+		return e.Encode(row)
+	})
+}
@@ -0,0 +1,118 @@
+package synth
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+func dataset(t *testing.T) *Dataset {
+	country, _ := discreteprobability.New([]string{"US", "DE"}, []float64{0.5, 0.5})
+	device, _ := discreteprobability.New([]string{"ios"}, []float64{1})
+	planUS, _ := discreteprobability.New([]string{"pro"}, []float64{1})
+	planDE, _ := discreteprobability.New([]string{"free"}, []float64{1})
+	plans, err := discreteprobability.NewConditional(map[string]*discreteprobability.Generator{"US": planUS, "DE": planDE})
+	if err != nil {
+		t.Errorf("NewConditional error %v", err)
+		t.FailNow()
+	}
+	price, _ := discreteprobability.New([]int{10}, []float64{1})
+	prices, _ := discreteprobability.NewConditional(map[string]*discreteprobability.Generator{"pro": price, "free": price})
+
+	// price is declared before the plan it depends on
+	d := New(Schema{"country": country, "device": device}).Given("price", "plan", prices).Given("plan", "country", plans)
+	d.SetSeed(1)
+	return d
+}
+
+func TestGenerateRows(t *testing.T) {
+	d := dataset(t)
+	rows, err := d.GenerateRows(1000)
+	if err != nil {
+		t.Errorf("GenerateRows error %v", err)
+		t.FailNow()
+	}
+	us := 0
+	for _, row := range rows {
+		plan := map[interface{}]string{"US": "pro", "DE": "free"}[row["country"]]
+		if row["plan"] != plan || row["device"] != "ios" || row["price"] != 10 {
+			t.Errorf("unexpected row %v", row)
+			t.FailNow()
+		}
+		if row["country"] == "US" {
+			us++
+		}
+	}
+	if us < 400 || us > 600 {
+		t.Errorf("expected about 500 US rows, got %v", us)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	if err := dataset(t).WriteCSV(&buf, 2); err != nil {
+		t.Errorf("WriteCSV error %v", err)
+		t.FailNow()
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || lines[0] != "country,device,plan,price" {
+		t.Errorf("unexpected csv %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := dataset(t).WriteJSON(&buf, 2); err != nil {
+		t.Errorf("WriteJSON error %v", err)
+		t.FailNow()
+	}
+	var row map[string]interface{}
+	if err := json.NewDecoder(&buf).Decode(&row); err != nil || row["device"] != "ios" {
+		t.Errorf("unexpected json row %v, error %v", row, err)
+	}
+}
+
+// failWriter fails after the first write.
+type failWriter struct {
+	writes int
+}
+
+func (w *failWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.writes > 1 {
+		return 0, errors.New("disk full")
+	}
+	return len(p), nil
+}
+
+func TestWriteStream(t *testing.T) {
+	if rows, err := dataset(t).GenerateRows(-1); rows != nil || err != nil {
+		t.Errorf("expected no rows for negative n, got %v %v", rows, err)
+	}
+
+	// the rows are written as they are generated, so a huge export stops at the first error
+	w := &failWriter{}
+	if err := dataset(t).WriteJSON(w, 1e12); err == nil || w.writes != 2 {
+		t.Errorf("expected to stop at the second write, got %v after %v writes", err, w.writes)
+	}
+}
+
+func TestColumnErrors(t *testing.T) {
+	g, _ := discreteprobability.New([]string{"a"}, []float64{1})
+	table, _ := discreteprobability.NewConditional(map[string]*discreteprobability.Generator{"a": g})
+
+	if _, err := New(Schema{"x": g}).Given("x", "x", table).GenerateRows(1); err != ErrDuplicate {
+		t.Errorf("expected ErrDuplicate, got %v", err)
+	}
+	if _, err := New(Schema{"x": g}).Given("y", "z", table).GenerateRows(1); err != discreteprobability.ErrColumn {
+		t.Errorf("expected ErrColumn, got %v", err)
+	}
+	if _, err := New(Schema{"x": g}).Given("y", "z", table).Given("z", "y", table).GenerateRows(1); err != ErrCycle {
+		t.Errorf("expected ErrCycle, got %v", err)
+	}
+	if _, err := New(Schema{"x": g}).Given("y", "x", table).Given("z", "y", table).GenerateRows(1); err != nil {
+		t.Errorf("GenerateRows error %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package synth
+
+import (
+	"io"
+	"strings"
+	"text/template"
+)
+
+// Template renders a text/template whose fields are drawn from the generators of a Schema on
+// every render, e.g. "Hello {{.greeting}} from {{.city}}" for test fixtures with realistic
+// frequencies of the words.
+type Template struct {
+	t      *template.Template
+	schema Schema
+}
+
+// NewTemplate parses the text of the template with the bindings of its fields.
+// It will return the parse error of text/template. A field without a binding is an error
+// of Execute.
+func NewTemplate(text string, bindings Schema) (*Template, error) {
+	t, err := template.New("synth").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{t: t, schema: bindings}, nil
+}
+
+// SetSeed is to set a custom random seed other than the time stamp. The generators are
+// reseeded in the order of their names, so the renders are reproducible.
+func (t *Template) SetSeed(s int64) {
+	New(t.schema).SetSeed(s)
+}
+
+// Execute draws a value of every binding and writes the template with them to w.
+func (t *Template) Execute(w io.Writer) error {
+	data := make(map[string]interface{}, len(t.schema))
+	for name, g := range t.schema {
+		data[name] = g.RandomInterface()
+	}
+	return t.t.Execute(w, data)
+}
+
+// Render returns the template with a value drawn for every binding.
+func (t *Template) Render() (string, error) {
+	var b strings.Builder
+	if err := t.Execute(&b); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
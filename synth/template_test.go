@@ -0,0 +1,41 @@
+package synth
+
+import (
+	"testing"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+func TestTemplate(t *testing.T) {
+	greeting, _ := discreteprobability.New([]string{"Hi", "Hello"}, []float64{0.8, 0.2})
+	city, _ := discreteprobability.New([]string{"Paris"}, []float64{1})
+	tmpl, err := NewTemplate("{{.greeting}} from {{.city}}", Schema{"greeting": greeting, "city": city})
+	if err != nil {
+		t.Errorf("NewTemplate error %v", err)
+		t.FailNow()
+	}
+	tmpl.SetSeed(1)
+
+	count := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		s, err := tmpl.Render()
+		if err != nil {
+			t.Errorf("Render error %v", err)
+			t.FailNow()
+		}
+		count[s]++
+	}
+	if len(count) != 2 || count["Hi from Paris"] < 7800 || count["Hi from Paris"] > 8200 {
+		t.Errorf("unexpected renders %v", count)
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+	if _, err := NewTemplate("{{.greeting", nil); err == nil {
+		t.Errorf("expected a parse error")
+	}
+	tmpl, _ := NewTemplate("{{.greeting}}", Schema{})
+	if _, err := tmpl.Render(); err == nil {
+		t.Errorf("expected an error of the missing binding")
+	}
+}
@@ -0,0 +1,23 @@
+package discreteprobability
+
+// SystematicSample returns k values with probability-proportional-to-size systematic
+// sampling: a random start in [0, 1/k) followed by a fixed skip of 1/k over the
+// cumulative weights. A value with a probability above 1/k can be selected more than once.
+func (g *Generator) SystematicSample(k int) []interface{} {
+	if k <= 0 {
+		return nil
+	}
+
+	step := 1 / float64(k)
+	point := uniform(g.source) * step
+	samples := make([]interface{}, 0, k)
+	i := 0
+	for n := 0; n < k; n++ {
+		for i < g.size-1 && g.weights[i] < point {
+			i++
+		}
+		samples = append(samples, g.values[i].Interface())
+		point += step
+	}
+	return samples
+}
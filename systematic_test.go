@@ -0,0 +1,39 @@
+package discreteprobability
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSystematicSample(t *testing.T) {
+	g := generateInt(t, time.Now().Unix(), sliceLen)
+	samples := g.SystematicSample(sliceLen)
+	if len(samples) != sliceLen {
+		t.Errorf("expected %v samples, got %v", sliceLen, samples)
+		t.FailNow()
+	}
+
+	// equal weights with k equal to the size selects every value once
+	seen := map[int]bool{}
+	for _, s := range samples {
+		seen[s.(int)] = true
+	}
+	if len(seen) != sliceLen {
+		t.Errorf("expected every value once, got %v", samples)
+	}
+}
+
+func TestSystematicSampleLargeWeight(t *testing.T) {
+	g, _ := New([]string{"a", "b", "c"}, []float64{0.6, 0.2, 0.2})
+	g.SetSeed(time.Now().Unix())
+
+	count := 0
+	for _, s := range g.SystematicSample(5) {
+		if s == "a" {
+			count++
+		}
+	}
+	if count != 3 {
+		t.Errorf("expected a to be selected 3 times, got %v", count)
+	}
+}
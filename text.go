@@ -0,0 +1,52 @@
+package discreteprobability
+
+import (
+	"reflect"
+)
+
+// MarshalText encodes a string or int generator as "value:weight,value:weight".
+// It will return ErrType for other types of values, or ErrSpec if a string value contains a comma.
+func (g *Generator) MarshalText() ([]byte, error) {
+	s, err := g.Spec()
+	if err != nil {
+		return nil, err
+	}
+	return s.MarshalText()
+}
+
+// UnmarshalText decodes a "value:weight,value:weight" spec into the generator.
+// If the generator already has values, the decoded values keep their type, otherwise
+// the values are ints when all of them are integers and strings if not.
+// The seed, the buffer pool and the draw hooks of the generator are kept.
+func (g *Generator) UnmarshalText(text []byte) error {
+	var s Spec
+	if g.size != 0 {
+		switch g.values[0].Kind() {
+		case reflect.Int:
+			s.Values = []int{}
+		case reflect.String:
+			s.Values = []string{}
+		default:
+			return ErrType
+		}
+	}
+	if err := s.UnmarshalText(text); err != nil {
+		return err
+	}
+
+	n, err := s.Generator()
+	if err != nil {
+		return err
+	}
+
+	if g.source != nil {
+		n.source = g.source
+		n.tickSeed = g.tickSeed
+	}
+	n.buffers = g.buffers
+	n.onDraw = g.onDraw
+	n.tracer = g.tracer
+	n.logger = g.logger
+	*g = *n
+	return nil
+}
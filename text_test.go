@@ -0,0 +1,107 @@
+package discreteprobability
+
+import (
+	"encoding"
+	"sync"
+	"testing"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*Generator)(nil)
+	_ encoding.TextUnmarshaler = (*Generator)(nil)
+)
+
+func TestMarshalText(t *testing.T) {
+	g, _ := New([]string{"a", "b", "c"}, []float64{0.2, 0.5, 0.3})
+	text, err := g.MarshalText()
+	if err != nil {
+		t.Errorf("MarshalText error %v", err)
+		t.FailNow()
+	}
+	if string(text) != "b:0.5,c:0.3,a:0.2" {
+		t.Errorf("unexpected text %v", string(text))
+	}
+
+	f := generateFloat64(t, 1, sliceLen)
+	if _, err := f.MarshalText(); err != ErrType {
+		t.Errorf("expected ErrType, got %v", err)
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	var g Generator
+	if err := g.UnmarshalText([]byte("1:0.25, 2:0.75")); err != nil {
+		t.Errorf("UnmarshalText error %v", err)
+		t.FailNow()
+	}
+	if _, err := g.RandomIntSafe(); err != nil {
+		t.Errorf("expected int generator, got %v", err)
+	}
+	if !weightEqual(&g, 2, 0.75) {
+		t.Errorf("unexpected generator %v", &g)
+	}
+
+	var s Generator
+	if err := s.UnmarshalText([]byte("a:0.5,b:0.5")); err != nil {
+		t.Errorf("UnmarshalText error %v", err)
+		t.FailNow()
+	}
+	if _, err := s.RandomStringSafe(); err != nil {
+		t.Errorf("expected string generator, got %v", err)
+	}
+
+	if err := g.UnmarshalText([]byte("a:0.5,b:0.5")); err != ErrSpec {
+		t.Errorf("expected ErrSpec for string values in int generator, got %v", err)
+	}
+	if err := s.UnmarshalText([]byte("a=0.5")); err != ErrSpec {
+		t.Errorf("expected ErrSpec, got %v", err)
+	}
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	g := generateString(t, 1, sliceLen)
+	text, err := g.MarshalText()
+	if err != nil {
+		t.Errorf("MarshalText error %v", err)
+		t.FailNow()
+	}
+
+	var n Generator
+	n.values = g.values[:1]
+	n.size = 1
+	if err := n.UnmarshalText(text); err != nil {
+		t.Errorf("UnmarshalText error %v", err)
+		t.FailNow()
+	}
+	if !g.Equal(&n) {
+		t.Errorf("round trip changed the generator: %v", g.Diff(&n))
+	}
+}
+
+func TestUnmarshalTextKeepsState(t *testing.T) {
+	draws := 0
+	pool := &sync.Pool{}
+	g, _ := New([]int{1, 2}, []float64{0.5, 0.5})
+	g.SetSeed(42)
+	g.WithBufferPool(pool).WithOnDraw(func(int, interface{}, float64) { draws++ })
+	if err := g.UnmarshalText([]byte("1:0.25,2:0.25,3:0.5")); err != nil {
+		t.Errorf("UnmarshalText error %v", err)
+		t.FailNow()
+	}
+	if g.buffers != pool {
+		t.Errorf("expected the buffer pool to be kept")
+	}
+	g.RandomInt()
+	if draws != 1 {
+		t.Errorf("expected the draw hook to be kept, got %d draws", draws)
+	}
+
+	replay, _ := New([]int{1, 2, 3}, []float64{0.25, 0.25, 0.5})
+	replay.SetSeed(42)
+	for tick := uint64(0); tick < 100; tick++ {
+		if a, b := g.RandomAtTick(tick), replay.RandomAtTick(tick); a != b {
+			t.Errorf("tick %d drew %v and %v with the same seed", tick, a, b)
+			t.FailNow()
+		}
+	}
+}
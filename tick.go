@@ -0,0 +1,11 @@
+package discreteprobability
+
+// RandomAtTick returns the value drawn purely from the seed of the generator and the tick, e.g. the
+// frame of a game loop. The same seed and tick always give the same value, regardless of the other
+// draws, the order of the calls or the ticks which were skipped, so a replay or another server with
+// the same seed gets identical results. The random stream of the generator is not touched.
+// The seed is the one of SetSeed, or the time stamp if it was not set.
+func (g *Generator) RandomAtTick(tick uint64) interface{} {
+	base := splitmix{state: uint64(g.tickSeed)}
+	return g.RandomSeeded(base.Uint64() + tick)
+}
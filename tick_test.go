@@ -0,0 +1,47 @@
+package discreteprobability
+
+import (
+	"testing"
+)
+
+func TestRandomAtTick(t *testing.T) {
+	g, _ := New([]int{1, 2, 3}, []float64{0.2, 0.3, 0.5})
+	g.SetSeed(42)
+	replay, _ := New([]int{1, 2, 3}, []float64{0.2, 0.3, 0.5})
+	replay.SetSeed(42)
+
+	occurrence := map[int]float64{}
+	for tick := uint64(0); tick < repeats; tick++ {
+		v := g.RandomAtTick(tick).(int)
+		occurrence[v]++
+		// the other draws don't change the draws of the ticks
+		g.RandomInt()
+		// the replay skips the odd ticks and draws the even ones twice
+		if tick%2 == 0 {
+			replay.RandomAtTick(tick)
+			if r := replay.RandomAtTick(tick); r != v {
+				t.Errorf("tick %v expected %v, got %v", tick, v, r)
+				t.FailNow()
+			}
+		}
+	}
+	for v, w := range map[int]float64{1: 0.2, 2: 0.3, 3: 0.5} {
+		p := w * repeats
+		if d := p * 3 / 100; occurrence[v] > p+d || occurrence[v] < p-d {
+			t.Errorf("incorrect distribution value %v, expected %f, got %f", v, p, occurrence[v])
+		}
+	}
+
+	other, _ := New([]int{1, 2, 3}, []float64{0.2, 0.3, 0.5})
+	other.SetSeed(43)
+	same := 0
+	for tick := uint64(0); tick < 1000; tick++ {
+		if other.RandomAtTick(tick) == g.RandomAtTick(tick) {
+			same++
+		}
+	}
+	// 0.2² + 0.3² + 0.5² = 38% of the ticks match by chance
+	if same > 450 {
+		t.Errorf("another seed gives the same draws at %v of 1000 ticks", same)
+	}
+}
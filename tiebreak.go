@@ -0,0 +1,40 @@
+package discreteprobability
+
+import (
+	"math"
+	"sort"
+)
+
+// WithTieBreak returns a copy of g in which the values with equal weights are ordered by less.
+// The values are sorted by weight only, so the order of the values with equal weights follows
+// the input, and so do Describe, Spec, RankedDraw and the values drawn with a seed. With a tie
+// break they are the same regardless of the order of the input. g itself is not changed.
+// The random stream of the new Generator is seeded from g.
+func (g *Generator) WithTieBreak(less func(a, b interface{}) bool) *Generator {
+	c := g.Clone()
+	// the values are reordered, so the copy doesn't share the value order of g
+	c.order = &valueOrder{}
+	start := 0
+	for i := 1; i <= c.size; i++ {
+		if i < c.size && c.tied(start, i) {
+			continue
+		}
+		// the cumulative weights don't change within a run of equal weights
+		run := c.values[start:i]
+		sort.SliceStable(run, func(a, b int) bool {
+			return less(run[a].Interface(), run[b].Interface())
+		})
+		start = i
+	}
+	return c
+}
+
+// tied reports whether the values at i and j have equal weights. The float weights are
+// compared relative to their size, as they are the differences of the cumulative weights.
+func (g *Generator) tied(i, j int) bool {
+	if g.exact != nil {
+		return g.exact.weight(i).Cmp(g.exact.weight(j)) == 0
+	}
+	p, q := g.probability(i), g.probability(j)
+	return abs(p-q) <= weightEpsilon*math.Max(p, q)
+}
@@ -0,0 +1,74 @@
+package discreteprobability
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestWithTieBreak(t *testing.T) {
+	less := func(a, b interface{}) bool { return a.(string) < b.(string) }
+	g1, _ := New([]string{"c", "a", "x", "b"}, []float64{0.25, 0.25, 0.25, 0.25})
+	g2, _ := New([]string{"b", "x", "a", "c"}, []float64{0.25, 0.25, 0.25, 0.25})
+	g1, g2 = g1.WithTieBreak(less), g2.WithTieBreak(less)
+	if g1.Describe() != g2.Describe() {
+		t.Errorf("expected the same order, got\n%v\n%v", g1.Describe(), g2.Describe())
+	}
+
+	g1.SetSeed(1)
+	g2.SetSeed(1)
+	for i := 0; i < 100; i++ {
+		if v1, v2 := g1.RandomString(), g2.RandomString(); v1 != v2 {
+			t.Errorf("expected the same values with the same seed, got %v and %v", v1, v2)
+			t.FailNow()
+		}
+	}
+
+	// the values with different weights keep their order
+	g, _ := New([]string{"b", "z", "a"}, []float64{0.25, 0.5, 0.25})
+	g = g.WithTieBreak(less)
+	if s := g.String(); s != "Generator{z: 0.5, b: 0.25, a: 0.25}" {
+		t.Errorf("unexpected order %v", s)
+	}
+}
+
+func TestWithTieBreakExact(t *testing.T) {
+	g, err := NewExact([]int{3, 1, 2}, []*big.Rat{big.NewRat(1, 4), big.NewRat(1, 4), big.NewRat(1, 2)})
+	if err != nil {
+		t.Errorf("NewExact error %v", err)
+		t.FailNow()
+	}
+	g = g.WithTieBreak(func(a, b interface{}) bool { return a.(int) < b.(int) })
+	if s := g.String(); s != "Generator{2: 0.5, 3: 0.25, 1: 0.25}" {
+		t.Errorf("unexpected order %v", s)
+	}
+}
+
+func TestWithTieBreakCopy(t *testing.T) {
+	g, _ := New([]int{10, 20, 30}, []float64{0.25, 0.25, 0.5})
+	g.InverseCDF(0.1)
+	before := g.String()
+	c := g.WithTieBreak(func(a, b interface{}) bool { return a.(int) > b.(int) })
+	if g.String() != before {
+		t.Errorf("the generator was reordered, got %v", g)
+	}
+	for _, gen := range []*Generator{g, c} {
+		if v, _ := gen.InverseCDF(0.1); v != 10 {
+			t.Errorf("expected 10, got %v", v)
+		}
+		if v, _ := gen.InverseCDF(0.4); v != 20 {
+			t.Errorf("expected 20, got %v", v)
+		}
+	}
+}
+
+func TestWithTieBreakSmallWeights(t *testing.T) {
+	g, _ := New([]string{"a", "b", "c"}, []float64{1e-10, 5e-10, 1 - 6e-10})
+	c := g.WithTieBreak(func(a, b interface{}) bool { return a.(string) > b.(string) })
+	for i, value := range c.values {
+		p := map[string]float64{"a": 1e-10, "b": 5e-10, "c": 1 - 6e-10}[value.String()]
+		if math.Abs(c.probability(i)-p) > p*1e-6 {
+			t.Errorf("expected %v for %v, got %v", p, value, c.probability(i))
+		}
+	}
+}
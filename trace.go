@@ -0,0 +1,41 @@
+package discreteprobability
+
+// Tracer records the draws as events, e.g. on a span of OpenTelemetry. The attributes are
+// the value, its probability and the algorithm of the draw, see Algorithm. This package
+// doesn't depend on OpenTelemetry, an adapter of a trace.Span is a few lines:
+//
+//	type spanTracer struct{ span trace.Span }
+//
+//	func (s spanTracer) Event(name string, attributes map[string]interface{}) {
+//		kv := make([]attribute.KeyValue, 0, len(attributes))
+//		for k, v := range attributes {
+//			kv = append(kv, attribute.String(k, fmt.Sprint(v)))
+//		}
+//		s.span.AddEvent(name, trace.WithAttributes(kv...))
+//	}
+type Tracer interface {
+	Event(name string, attributes map[string]interface{})
+}
+
+// WithTracer sets the tracer of g and returns g. An event is recorded on every draw, see WithOnDraw,
+// and a single event for the whole batch of SampleN or SampleLatin. The tracer should be safe for
+// concurrent use with Freeze and Sharded.
+func (g *Generator) WithTracer(t Tracer) *Generator {
+	g.tracer = t
+	return g
+}
+
+func (g *Generator) traceDraw(i int) {
+	g.tracer.Event("discreteprobability.draw", map[string]interface{}{
+		"value":       g.values[i].Interface(),
+		"probability": g.probability(i),
+		"algorithm":   g.Algorithm(),
+	})
+}
+
+func (g *Generator) traceBatch(n int) {
+	g.tracer.Event("discreteprobability.batch", map[string]interface{}{
+		"count":     n,
+		"algorithm": g.Algorithm(),
+	})
+}
@@ -0,0 +1,46 @@
+package discreteprobability
+
+import (
+	"testing"
+)
+
+type event struct {
+	name       string
+	attributes map[string]interface{}
+}
+
+// recorder is a Tracer which keeps the events.
+type recorder struct {
+	events []event
+}
+
+func (r *recorder) Event(name string, attributes map[string]interface{}) {
+	r.events = append(r.events, event{name, attributes})
+}
+
+func TestWithTracer(t *testing.T) {
+	g, err := New([]string{"a", "b"}, []float64{0.25, 0.75})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+	r := &recorder{}
+	g.WithTracer(r)
+
+	v := g.RandomString()
+	g.SampleN(100)
+	if len(r.events) != 2 {
+		t.Errorf("expected 2 events, got %v", r.events)
+		t.FailNow()
+	}
+
+	draw := r.events[0]
+	if draw.name != "discreteprobability.draw" || draw.attributes["value"] != v ||
+		!weightEqual(g, v, draw.attributes["probability"].(float64)) || draw.attributes["algorithm"] != "linear" {
+		t.Errorf("unexpected draw event %v", draw)
+	}
+	batch := r.events[1]
+	if batch.name != "discreteprobability.batch" || batch.attributes["count"] != 100 {
+		t.Errorf("unexpected batch event %v", batch)
+	}
+}
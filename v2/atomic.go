@@ -0,0 +1,60 @@
+package discreteprobability
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// AtomicGenerator is a Generator which can be swapped for another one while it's in use, e.g. by
+// a Rollout. It's safe for concurrent use.
+type AtomicGenerator[T any] struct {
+	mu  sync.Mutex
+	g   *Generator[T]
+	rnd *rand.Rand
+}
+
+var _ BatchSampler[int] = (*AtomicGenerator[int])(nil)
+
+// NewAtomic returns a new AtomicGenerator which draws from g until it's swapped.
+// A nil g is a zero Generator, which draws the zero value of T.
+func NewAtomic[T any](g *Generator[T]) *AtomicGenerator[T] {
+	a := &AtomicGenerator[T]{rnd: rand.New(rand.NewSource(time.Now().UnixNano()))}
+	a.Store(g)
+	return a
+}
+
+// SetSeed is to set a custom random seed other than the time stamp. The stored generators
+// are seeded from it.
+func (a *AtomicGenerator[T]) SetSeed(s int64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.rnd = rand.New(rand.NewSource(s))
+	a.g.SetSeed(a.rnd.Int63())
+}
+
+// Store swaps the generator for g. The draws after Store are made with g, which is reseeded
+// and should not be used elsewhere. A nil g is a zero Generator.
+func (a *AtomicGenerator[T]) Store(g *Generator[T]) {
+	if g == nil {
+		g = &Generator[T]{}
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	g.SetSeed(a.rnd.Int63())
+	a.g = g
+}
+
+// Random returns the value from the value set with the weights of the current generator.
+func (a *AtomicGenerator[T]) Random() T {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.g.Random()
+}
+
+// SampleN returns n values drawn independently from the current generator.
+func (a *AtomicGenerator[T]) SampleN(n int) []T {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.g.SampleN(n)
+}
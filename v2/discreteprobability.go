@@ -0,0 +1,170 @@
+// Package discreteprobability is the v2 of the generator of random values with corresponding
+// weights. The Generator is generic over the type of the values, so there is no reflection and
+// no type assertion, and no accessor can panic: a zero Generator, which has no values,
+// draws the zero value of T. Example usage:
+//
+//	generator, err := discreteprobability.New([]int{1, 2, 3}, []float64{0.25, 0.5, 0.25})
+//	if err != nil {
+//		panic(err) // Error handlers
+//	}
+//	num := generator.Random()
+//
+//	The num would have a 50% probability value of 2, a 25% probability value of 1 or 3.
+//
+// A v1 generator is converted with FromV1.
+package discreteprobability
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	v1 "github.com/peterli110/discreteprobability"
+)
+
+// The errors are the ones of v1, so the error checks don't change with the migration.
+var (
+	// ErrType is returned when a v1 value is not of the type of the v2 Generator
+	ErrType = v1.ErrType
+	// ErrLength is returned when the length of values and weights are different
+	ErrLength = v1.ErrLength
+	// ErrWeightSum is returned when the sum of weights is not 1
+	ErrWeightSum = v1.ErrWeightSum
+	// ErrNegativeWeight is returned when any of the weights is negative
+	ErrNegativeWeight = v1.ErrNegativeWeight
+	// ErrEmptyInput is returned when there are no values
+	ErrEmptyInput = v1.ErrEmptyInput
+	// ErrRate is returned when a rate of events is not positive
+	ErrRate = v1.ErrRate
+)
+
+// tolerance is the largest difference from 1 of the sum of weights, the same as v1.
+const tolerance = 1e-4
+
+// Generator stores the values and the cumulative weights in the order of the input,
+// and generates random values of type T with the corresponding weights.
+type Generator[T any] struct {
+	values     []T
+	cumulative []float64
+	source     rand.Source
+}
+
+// New returns a new Generator. It will return error if there are no values, values and weights
+// have different length, any weight is negative or the sum of weights not equal to 1
+func New[T any](values []T, weights []float64) (*Generator[T], error) {
+	if len(values) != len(weights) {
+		return nil, ErrLength
+	}
+	if len(values) == 0 {
+		return nil, ErrEmptyInput
+	}
+
+	cumulative, err := accumulate(weights)
+	if err != nil {
+		return nil, err
+	}
+	return &Generator[T]{
+		values:     append([]T(nil), values...),
+		cumulative: cumulative,
+		source:     rand.NewSource(time.Now().UnixNano()),
+	}, nil
+}
+
+// accumulate returns the cumulative weights. It will return error if any weight is negative
+// or the sum of weights not equal to 1
+func accumulate(weights []float64) ([]float64, error) {
+	cumulative := make([]float64, len(weights))
+	sum := float64(0)
+	for i, w := range weights {
+		if w < 0 {
+			return nil, ErrNegativeWeight
+		}
+		sum += w
+		cumulative[i] = sum
+	}
+	if !(math.Abs(sum-1) <= tolerance) {
+		return nil, ErrWeightSum
+	}
+	return cumulative, nil
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (g *Generator[T]) SetSeed(s int64) {
+	g.source = rand.NewSource(s)
+}
+
+// Len returns the number of values.
+func (g *Generator[T]) Len() int {
+	return len(g.values)
+}
+
+// index returns the index of a value drawn with the weights, or -1 if there are no values.
+func (g *Generator[T]) index() int {
+	// a single value is always drawn, without the random source
+	if len(g.cumulative) <= 1 {
+		return len(g.cumulative) - 1
+	}
+	f := float64(g.source.Int63()) / (1 << 63) * g.cumulative[len(g.cumulative)-1]
+	i := sort.Search(len(g.cumulative), func(i int) bool {
+		return g.cumulative[i] > f
+	})
+	// f is below the sum, the check is only for the rounding
+	if i == len(g.cumulative) {
+		i--
+	}
+	return i
+}
+
+// Random returns the value from the value set with corresponding weights,
+// or the zero value if there are no values.
+func (g *Generator[T]) Random() T {
+	var v T
+	if i := g.index(); i >= 0 {
+		v = g.values[i]
+	}
+	return v
+}
+
+// RandomWeighted returns the value from the value set with corresponding weights
+// together with the probability of drawing it, or the zero value and 0 if there are no values.
+func (g *Generator[T]) RandomWeighted() (T, float64) {
+	i := g.index()
+	if i < 0 {
+		var v T
+		return v, 0
+	}
+	p := g.cumulative[i]
+	if i > 0 {
+		p -= g.cumulative[i-1]
+	}
+	return g.values[i], p
+}
+
+// SampleN returns n values drawn independently with the corresponding weights,
+// or nil if n is not positive.
+func (g *Generator[T]) SampleN(n int) []T {
+	if n <= 0 {
+		return nil
+	}
+	out := make([]T, n)
+	for i := range out {
+		out[i] = g.Random()
+	}
+	return out
+}
+
+// SetWeights replaces the weights, which are in the order of the values. It will return error
+// if values and weights have different length, any weight is negative or the sum of weights
+// not equal to 1, and the weights are unchanged in that case.
+func (g *Generator[T]) SetWeights(weights []float64) error {
+	if len(weights) != len(g.values) {
+		return ErrLength
+	}
+	cumulative, err := accumulate(weights)
+	if err != nil {
+		return err
+	}
+	g.cumulative = cumulative
+	return nil
+}
@@ -0,0 +1,122 @@
+package discreteprobability
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+const repeats = 100000
+
+func TestRandom(t *testing.T) {
+	g, err := New([]string{"a", "b", "c"}, []float64{0.2, 0, 0.8})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+	g.SetSeed(time.Now().Unix())
+
+	occurrence := map[string]float64{}
+	for i := 0; i < repeats; i++ {
+		occurrence[g.Random()]++
+	}
+	if occurrence["b"] != 0 {
+		t.Errorf("value with zero weight was drawn %v times", occurrence["b"])
+	}
+	for v, w := range map[string]float64{"a": 0.2, "c": 0.8} {
+		p := w * repeats
+		if d := p * 3 / 100; occurrence[v] > p+d || occurrence[v] < p-d {
+			t.Errorf("incorrect distribution value %v, expected %f, got %f", v, p, occurrence[v])
+		}
+	}
+}
+
+func TestRandomWeighted(t *testing.T) {
+	g, err := New([]int{1, 2}, []float64{0.25, 0.75})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+	for i := 0; i < 100; i++ {
+		v, p := g.RandomWeighted()
+		if v == 1 && p != 0.25 || v == 2 && p != 0.75 {
+			t.Errorf("unexpected probability %v of %v", p, v)
+			t.FailNow()
+		}
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	if _, err := New([]int{1, 2}, []float64{1}); err != ErrLength {
+		t.Errorf("expected ErrLength, got %v", err)
+	}
+	if _, err := New([]int{1, 2}, []float64{0.5, 0.4}); err != ErrWeightSum {
+		t.Errorf("expected ErrWeightSum, got %v", err)
+	}
+	if _, err := New([]int{1, 2}, []float64{math.NaN(), 1}); err != ErrWeightSum {
+		t.Errorf("expected ErrWeightSum for NaN, got %v", err)
+	}
+	if _, err := New([]int{}, []float64{}); err != ErrEmptyInput {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := New([]int{1, 2}, []float64{-0.5, 1.5}); err != ErrNegativeWeight {
+		t.Errorf("expected ErrNegativeWeight, got %v", err)
+	}
+}
+
+func TestSampleN(t *testing.T) {
+	g, _ := New([]int{1, 2}, []float64{0, 1})
+	out := g.SampleN(10)
+	if len(out) != 10 {
+		t.Errorf("expected 10 values, got %v", len(out))
+		t.FailNow()
+	}
+	for _, v := range out {
+		if v != 2 {
+			t.Errorf("unexpected value %v", v)
+			t.FailNow()
+		}
+	}
+}
+
+func TestSetWeights(t *testing.T) {
+	g, _ := New([]int{1, 2}, []float64{0, 1})
+	if err := g.SetWeights([]float64{0.5}); err != ErrLength {
+		t.Errorf("expected ErrLength, got %v", err)
+	}
+	if err := g.SetWeights([]float64{0.5, 0.6}); err != ErrWeightSum {
+		t.Errorf("expected ErrWeightSum, got %v", err)
+	}
+	if v := g.Random(); v != 2 {
+		t.Errorf("weights changed by a failed update, got %v", v)
+	}
+
+	var u WeightedUpdater = g
+	if err := u.SetWeights([]float64{1, 0}); err != nil {
+		t.Errorf("SetWeights error %v", err)
+		t.FailNow()
+	}
+	for i := 0; i < 100; i++ {
+		if v := g.Random(); v != 1 {
+			t.Errorf("unexpected value %v", v)
+			t.FailNow()
+		}
+	}
+}
+
+func TestZeroGenerator(t *testing.T) {
+	var g Generator[string]
+	g.SetSeed(1)
+	if v := g.Random(); v != "" {
+		t.Errorf("expected the zero value, got %q", v)
+	}
+	if v, p := g.RandomWeighted(); v != "" || p != 0 {
+		t.Errorf("expected the zero value, got %q %v", v, p)
+	}
+	if s := g.SampleN(-1); s != nil {
+		t.Errorf("expected nil, got %v", s)
+	}
+	if v := NewAtomic[string](nil).Random(); v != "" {
+		t.Errorf("expected the zero value, got %q", v)
+	}
+}
@@ -0,0 +1,72 @@
+// Package discreteprobabilitytest provides a deterministic fake of the Sampler for unit tests.
+// Example usage:
+//
+//	sampler := discreteprobabilitytest.NewScripted("heads", "tails", "tails")
+//	checkout := NewCheckout(sampler) // any code depending on discreteprobability.Sampler[string]
+//
+//	The sampler returns "heads", "tails", "tails" and then starts over.
+package discreteprobabilitytest
+
+import (
+	"sync"
+
+	discreteprobability "github.com/peterli110/discreteprobability/v2"
+)
+
+// Scripted is a Sampler which returns a scripted sequence of values instead of random ones.
+// It is safe for concurrent use.
+type Scripted[T any] struct {
+	mu     sync.Mutex
+	values []T
+	next   int
+	calls  int
+}
+
+var _ discreteprobability.BatchSampler[int] = (*Scripted[int])(nil)
+
+// NewScripted returns a Scripted which returns the values in order, and starts over after the last one.
+// It panics if there are no values, as a fake without values is a bug in the test.
+func NewScripted[T any](values ...T) *Scripted[T] {
+	if len(values) == 0 {
+		panic(discreteprobability.ErrEmptyInput)
+	}
+	return &Scripted[T]{values: append([]T(nil), values...)}
+}
+
+// Random returns the next value of the script.
+func (s *Scripted[T]) Random() T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v := s.values[s.next]
+	s.next = (s.next + 1) % len(s.values)
+	s.calls++
+	return v
+}
+
+// SampleN returns the next n values of the script, or nil if n is not positive as the samplers do.
+func (s *Scripted[T]) SampleN(n int) []T {
+	if n <= 0 {
+		return nil
+	}
+	out := make([]T, n)
+	for i := range out {
+		out[i] = s.Random()
+	}
+	return out
+}
+
+// Calls returns the number of values returned so far, e.g. to check that the code under test
+// draws as often as expected.
+func (s *Scripted[T]) Calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+// Reset starts the script over.
+func (s *Scripted[T]) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next = 0
+	s.calls = 0
+}
@@ -0,0 +1,57 @@
+package discreteprobabilitytest
+
+import (
+	"testing"
+
+	discreteprobability "github.com/peterli110/discreteprobability/v2"
+)
+
+func draw(s discreteprobability.Sampler[string], n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = s.Random()
+	}
+	return out
+}
+
+func TestScripted(t *testing.T) {
+	s := NewScripted("a", "b", "c")
+	got := draw(s, 5)
+	for i, v := range []string{"a", "b", "c", "a", "b"} {
+		if got[i] != v {
+			t.Errorf("draw %v expected %v, got %v", i, v, got[i])
+			t.FailNow()
+		}
+	}
+	if s.Calls() != 5 {
+		t.Errorf("expected 5 calls, got %v", s.Calls())
+	}
+
+	s.Reset()
+	if v := s.Random(); v != "a" || s.Calls() != 1 {
+		t.Errorf("expected a after reset, got %v with %v calls", v, s.Calls())
+	}
+}
+
+func TestScriptedEmpty(t *testing.T) {
+	defer func() {
+		if r := recover(); r != discreteprobability.ErrEmptyInput {
+			t.Errorf("expected panic ErrEmptyInput, got %v", r)
+		}
+	}()
+	NewScripted[int]()
+}
+
+func TestScriptedSampleN(t *testing.T) {
+	var s discreteprobability.BatchSampler[int] = NewScripted(1, 2)
+	got := s.SampleN(3)
+	for i, v := range []int{1, 2, 1} {
+		if got[i] != v {
+			t.Errorf("draw %v expected %v, got %v", i, v, got[i])
+			t.FailNow()
+		}
+	}
+	if got := s.SampleN(-1); got != nil {
+		t.Errorf("expected nil for negative n, got %v", got)
+	}
+}
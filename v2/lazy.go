@@ -0,0 +1,124 @@
+package discreteprobability
+
+import (
+	"sync"
+	"time"
+)
+
+// WeightProvider returns the current weights in the order of the values, e.g. read from
+// Redis, a feature flag system or a database.
+type WeightProvider interface {
+	Weights() ([]float64, error)
+}
+
+// WeightProviderFunc is a function used as a WeightProvider.
+type WeightProviderFunc func() ([]float64, error)
+
+// Weights calls f.
+func (f WeightProviderFunc) Weights() ([]float64, error) {
+	return f()
+}
+
+// DefaultTTL is how long the weights of a Lazy are cached unless set with WithTTL.
+const DefaultTTL = time.Minute
+
+// Lazy is a Sampler whose weights are queried from a WeightProvider and cached for a ttl.
+// When the weights are older than the ttl, the next draw queries the provider again before
+// drawing. If the query fails or the weights are invalid, the old weights are kept and
+// the error is returned by Err. It's safe for concurrent use.
+type Lazy[T any] struct {
+	mu       sync.Mutex
+	g        *Generator[T]
+	provider WeightProvider
+	ttl      time.Duration
+	updated  time.Time
+	err      error
+	now      func() time.Time
+}
+
+var _ BatchSampler[int] = (*Lazy[int])(nil)
+
+// NewLazy returns a new Lazy over the values, with the weights of the provider.
+// It will return error if the provider fails or the weights are invalid, as for New
+func NewLazy[T any](values []T, provider WeightProvider) (*Lazy[T], error) {
+	weights, err := provider.Weights()
+	if err != nil {
+		return nil, err
+	}
+	g, err := New(values, weights)
+	if err != nil {
+		return nil, err
+	}
+	return &Lazy[T]{
+		g:        g,
+		provider: provider,
+		ttl:      DefaultTTL,
+		updated:  time.Now(),
+		now:      time.Now,
+	}, nil
+}
+
+// WithTTL sets how long the weights are cached and returns l.
+func (l *Lazy[T]) WithTTL(ttl time.Duration) *Lazy[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.ttl = ttl
+	return l
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (l *Lazy[T]) SetSeed(s int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.g.SetSeed(s)
+}
+
+// Refresh queries the provider now, regardless of the ttl.
+// It returns the error of the provider or of the weights, and the old weights are kept in that case.
+func (l *Lazy[T]) Refresh() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.refresh()
+}
+
+// refresh queries the provider and sets the weights.
+func (l *Lazy[T]) refresh() error {
+	l.updated = l.now()
+	weights, err := l.provider.Weights()
+	if err == nil {
+		err = l.g.SetWeights(weights)
+	}
+	l.err = err
+	return err
+}
+
+// maybeRefresh queries the provider if the weights are older than the ttl.
+func (l *Lazy[T]) maybeRefresh() {
+	if l.now().Sub(l.updated) >= l.ttl {
+		l.refresh()
+	}
+}
+
+// Err returns the error of the last query of the provider, or nil if it succeeded.
+func (l *Lazy[T]) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+// Random returns the value from the value set with the current weights.
+func (l *Lazy[T]) Random() T {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.maybeRefresh()
+	return l.g.Random()
+}
+
+// SampleN returns n values drawn independently with the current weights.
+// The weights are the same for all of them.
+func (l *Lazy[T]) SampleN(n int) []T {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.maybeRefresh()
+	return l.g.SampleN(n)
+}
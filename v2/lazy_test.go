@@ -0,0 +1,65 @@
+package discreteprobability
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLazy(t *testing.T) {
+	weights := []float64{1, 0}
+	var fail error
+	queries := 0
+	provider := WeightProviderFunc(func() ([]float64, error) {
+		queries++
+		return weights, fail
+	})
+	l, err := NewLazy([]string{"a", "b"}, provider)
+	if err != nil {
+		t.Errorf("NewLazy error %v", err)
+		t.FailNow()
+	}
+	now := time.Unix(0, 0)
+	l.now = func() time.Time { return now }
+	l.updated = now
+	l.WithTTL(time.Second)
+
+	weights = []float64{0, 1}
+	if v := l.Random(); v != "a" || queries != 1 {
+		t.Errorf("expected cached weights, got %v after %v queries", v, queries)
+	}
+
+	now = now.Add(time.Second)
+	if v := l.Random(); v != "b" || queries != 2 {
+		t.Errorf("expected refreshed weights, got %v after %v queries", v, queries)
+	}
+
+	// a failed query keeps the old weights
+	now = now.Add(time.Second)
+	fail = errors.New("unavailable")
+	if v := l.Random(); v != "b" || l.Err() != fail {
+		t.Errorf("expected old weights and the error, got %v and %v", v, l.Err())
+	}
+	fail = nil
+	weights = []float64{0.5, 0.6}
+	if err := l.Refresh(); err != ErrWeightSum {
+		t.Errorf("expected ErrWeightSum, got %v", err)
+	}
+	if v := l.Random(); v != "b" {
+		t.Errorf("expected old weights, got %v", v)
+	}
+}
+
+func TestNewLazyErrors(t *testing.T) {
+	fail := errors.New("unavailable")
+	if _, err := NewLazy([]int{1}, WeightProviderFunc(func() ([]float64, error) {
+		return nil, fail
+	})); err != fail {
+		t.Errorf("expected the provider error, got %v", err)
+	}
+	if _, err := NewLazy([]int{1}, WeightProviderFunc(func() ([]float64, error) {
+		return []float64{0.5, 0.5}, nil
+	})); err != ErrLength {
+		t.Errorf("expected ErrLength, got %v", err)
+	}
+}
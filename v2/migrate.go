@@ -0,0 +1,28 @@
+package discreteprobability
+
+import (
+	v1 "github.com/peterli110/discreteprobability"
+)
+
+// FromV1 returns a v2 Generator with the values and probabilities of the v1 generator,
+// to migrate the code which gets generators from v1 APIs such as NewFromCSV. The random
+// stream is seeded with the time stamp. It will return ErrType if any value is not of type T.
+//
+// The v1 accessors map to v2 as:
+//
+//	RandomInt, RandomFloat64, RandomString, RandomInterface  Random
+//	RandomIntSafe, RandomFloat64Safe, RandomStringSafe        Random, the type is checked by New
+//	RandomWeighted                                            RandomWeighted
+func FromV1[T any](g *v1.Generator) (*Generator[T], error) {
+	values, weights := g.Distribution()
+	typed := make([]T, len(values))
+	for i, v := range values {
+		t, ok := v.(T)
+		if !ok {
+			return nil, ErrType
+		}
+		typed[i] = t
+	}
+
+	return New(typed, weights)
+}
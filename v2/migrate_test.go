@@ -0,0 +1,35 @@
+package discreteprobability
+
+import (
+	"testing"
+
+	v1 "github.com/peterli110/discreteprobability"
+)
+
+func TestFromV1(t *testing.T) {
+	old, err := v1.New([]string{"a", "b"}, []float64{0.25, 0.75})
+	if err != nil {
+		t.Errorf("New error %v", err)
+		t.FailNow()
+	}
+
+	g, err := FromV1[string](old)
+	if err != nil {
+		t.Errorf("FromV1 error %v", err)
+		t.FailNow()
+	}
+	if g.Len() != 2 {
+		t.Errorf("expected 2 values, got %v", g.Len())
+	}
+	for i := 0; i < 100; i++ {
+		v, p := g.RandomWeighted()
+		if v == "a" && p != 0.25 || v == "b" && p != 0.75 {
+			t.Errorf("unexpected probability %v of %v", p, v)
+			t.FailNow()
+		}
+	}
+
+	if _, err := FromV1[int](old); err != ErrType {
+		t.Errorf("expected ErrType, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package discreteprobability
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// Mux returns a channel of the items read from the inputs with weighted preference, e.g. for
+// weighted fair scheduling of several work queues. For every item the inputs are tried in
+// a random order drawn with the weights, and the first ready one is read, so an empty input
+// falls back to the others. An input with a weight which is not positive is only read when
+// all the others are empty. When none is ready, the first item of any input is taken.
+// The channel is closed after all the inputs are closed. The items must be received until
+// then, or the goroutine of the Mux leaks.
+func Mux[T any](inputs map[<-chan T]float64) <-chan T {
+	chans := make([]<-chan T, 0, len(inputs))
+	weights := make([]float64, 0, len(inputs))
+	for c, w := range inputs {
+		chans = append(chans, c)
+		weights = append(weights, w)
+	}
+
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		keys := make([]float64, len(chans))
+		order := make([]int, len(chans))
+		open := len(chans)
+		for open > 0 {
+			// the order of the weighted random keys, Efraimidis-Spirakis
+			for i, w := range weights {
+				order[i] = i
+				keys[i] = math.Inf(-1)
+				if w > 0 {
+					keys[i] = -r.ExpFloat64() / w
+				}
+			}
+			sort.Slice(order, func(a, b int) bool {
+				return keys[order[a]] > keys[order[b]]
+			})
+
+			item, i, ok := receive(chans, order)
+			if !ok {
+				// a closed input is nil from now on, which is never ready
+				chans[i] = nil
+				open--
+				continue
+			}
+			out <- item
+		}
+	}()
+	return out
+}
+
+// receive reads from the first ready channel in the order, or blocks on all of them if
+// none is ready. It returns the index of the channel and false if it's closed.
+func receive[T any](chans []<-chan T, order []int) (T, int, bool) {
+	for _, i := range order {
+		if chans[i] == nil {
+			continue
+		}
+		select {
+		case item, ok := <-chans[i]:
+			return item, i, ok
+		default:
+		}
+	}
+
+	cases := make([]reflect.SelectCase, len(chans))
+	for i, c := range chans {
+		cases[i] = reflect.SelectCase{Dir: reflect.SelectRecv}
+		if c != nil {
+			cases[i].Chan = reflect.ValueOf(c)
+		}
+	}
+	i, v, ok := reflect.Select(cases)
+	if !ok {
+		var zero T
+		return zero, i, false
+	}
+	return v.Interface().(T), i, true
+}
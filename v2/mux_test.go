@@ -0,0 +1,59 @@
+package discreteprobability
+
+import (
+	"testing"
+)
+
+func TestMux(t *testing.T) {
+	a := make(chan string, repeats)
+	b := make(chan string, repeats)
+	for i := 0; i < repeats; i++ {
+		a <- "a"
+		b <- "b"
+	}
+	close(a)
+	close(b)
+
+	out := Mux(map[<-chan string]float64{a: 0.8, b: 0.2})
+	count := 0
+	for i := 0; i < repeats; i++ {
+		if <-out == "a" {
+			count++
+		}
+	}
+	p := float64(repeats) * 0.8
+	if d := p * 3 / 100; float64(count) > p+d || float64(count) < p-d {
+		t.Errorf("incorrect distribution, expected %f, got %d", p, count)
+	}
+
+	// the rest is read after the preferred input is empty, then the channel is closed
+	rest := 0
+	for range out {
+		rest++
+	}
+	if rest != repeats {
+		t.Errorf("expected %v more items, got %v", repeats, rest)
+	}
+}
+
+func TestMuxBlocking(t *testing.T) {
+	a := make(chan int)
+	b := make(chan int)
+	out := Mux(map[<-chan int]float64{a: 1, b: 0})
+	go func() {
+		b <- 1
+		a <- 2
+		close(a)
+		close(b)
+	}()
+
+	if v := <-out; v != 1 {
+		t.Errorf("expected 1, got %v", v)
+	}
+	if v := <-out; v != 2 {
+		t.Errorf("expected 2, got %v", v)
+	}
+	if _, ok := <-out; ok {
+		t.Errorf("expected the channel closed")
+	}
+}
@@ -0,0 +1,124 @@
+package discreteprobability
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// stage is a linear ramp of the weights to to over a duration.
+type stage[T comparable] struct {
+	to   map[T]float64
+	over time.Duration
+}
+
+// Rollout is a schedule of weights which ramp linearly from stage to stage, e.g. the share of a
+// canary ramping 1% to 5% to 25% to 100%.
+type Rollout[T comparable] struct {
+	from   map[T]float64
+	stages []stage[T]
+}
+
+// Ramp returns a Rollout whose weights ramp linearly from from to to over a duration.
+// The weights are relative, e.g. percentages, and a value missing in a map has zero weight.
+func Ramp[T comparable](from, to map[T]float64, over time.Duration) *Rollout[T] {
+	return &Rollout[T]{from: from, stages: []stage[T]{{to: to, over: over}}}
+}
+
+// Then adds a stage which ramps from the last weights to to over a duration, and returns r.
+func (r *Rollout[T]) Then(to map[T]float64, over time.Duration) *Rollout[T] {
+	r.stages = append(r.stages, stage[T]{to: to, over: over})
+	return r
+}
+
+// Duration returns the total duration of the stages.
+func (r *Rollout[T]) Duration() time.Duration {
+	total := time.Duration(0)
+	for _, s := range r.stages {
+		total += s.over
+	}
+	return total
+}
+
+// WeightsAt returns the weights after elapsed from the start of the rollout, scaled to sum to 1.
+// After the last stage, the weights are the final ones.
+func (r *Rollout[T]) WeightsAt(elapsed time.Duration) map[T]float64 {
+	from := r.from
+	for _, s := range r.stages {
+		if elapsed < s.over {
+			f := float64(elapsed) / float64(s.over)
+			return scale(interpolate(from, s.to, f))
+		}
+		elapsed -= s.over
+		from = s.to
+	}
+	return scale(interpolate(from, from, 1))
+}
+
+// interpolate returns the weights a fraction f of the way from a to b.
+func interpolate[T comparable](a, b map[T]float64, f float64) map[T]float64 {
+	w := make(map[T]float64, len(a)+len(b))
+	for v, weight := range a {
+		w[v] += (1 - f) * weight
+	}
+	for v, weight := range b {
+		w[v] += f * weight
+	}
+	return w
+}
+
+// scale divides the weights by their sum, unless it's not positive.
+func scale[T comparable](w map[T]float64) map[T]float64 {
+	sum := float64(0)
+	for _, weight := range w {
+		sum += weight
+	}
+	if sum > 0 {
+		for v := range w {
+			w[v] /= sum
+		}
+	}
+	return w
+}
+
+// generator returns a Generator of the weights. The values are sorted by their fmt %v
+// representation, so a seeded rollout is reproducible.
+func generator[T comparable](w map[T]float64) (*Generator[T], error) {
+	values := make([]T, 0, len(w))
+	for v := range w {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool { return fmt.Sprint(values[i]) < fmt.Sprint(values[j]) })
+	weights := make([]float64, len(values))
+	for i, v := range values {
+		weights[i] = w[v]
+	}
+	return New(values, weights)
+}
+
+// Run stores a generator of the current weights into a every step, from now until the end of the
+// rollout or until ctx is done. It returns nil once the final weights are stored, the error of ctx,
+// or the error of New if the weights are invalid, e.g. negative, and a is unchanged in that case.
+func (r *Rollout[T]) Run(ctx context.Context, a *AtomicGenerator[T], step time.Duration) error {
+	start := time.Now()
+	ticker := time.NewTicker(step)
+	defer ticker.Stop()
+	for {
+		elapsed := time.Since(start)
+		g, err := generator(r.WeightsAt(elapsed))
+		if err != nil {
+			return err
+		}
+		a.Store(g)
+		if elapsed >= r.Duration() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package discreteprobability
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRolloutWeights(t *testing.T) {
+	r := Ramp(map[string]float64{"stable": 99, "canary": 1}, map[string]float64{"stable": 95, "canary": 5}, time.Hour).
+		Then(map[string]float64{"stable": 75, "canary": 25}, time.Hour).
+		Then(map[string]float64{"canary": 100}, 2*time.Hour)
+	if r.Duration() != 4*time.Hour {
+		t.Errorf("expected 4h, got %v", r.Duration())
+	}
+
+	for elapsed, canary := range map[time.Duration]float64{
+		0:                0.01,
+		30 * time.Minute: 0.03,
+		time.Hour:        0.05,
+		90 * time.Minute: 0.15,
+		3 * time.Hour:    0.625,
+		5 * time.Hour:    1,
+	} {
+		if w := r.WeightsAt(elapsed); math.Abs(w["canary"]-canary) > 1e-9 || math.Abs(w["stable"]-(1-canary)) > 1e-9 {
+			t.Errorf("weights after %v expected canary %v, got %v", elapsed, canary, w)
+		}
+	}
+}
+
+func TestRolloutRun(t *testing.T) {
+	g, _ := New([]string{"stable"}, []float64{1})
+	a := NewAtomic(g)
+	a.SetSeed(1)
+	r := Ramp(map[string]float64{"stable": 1}, map[string]float64{"canary": 1}, 20*time.Millisecond)
+	if err := r.Run(context.Background(), a, time.Millisecond); err != nil {
+		t.Errorf("Run error %v", err)
+		t.FailNow()
+	}
+	for i := 0; i < 100; i++ {
+		if v := a.Random(); v != "canary" {
+			t.Errorf("expected canary after the rollout, got %v", v)
+			t.FailNow()
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := Ramp(map[string]float64{"a": 1}, map[string]float64{"b": 1}, time.Hour).Run(ctx, a, time.Second); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if err := Ramp(map[string]float64{"a": -1}, map[string]float64{"b": 1}, time.Hour).Run(ctx, a, time.Second); err == nil {
+		t.Errorf("expected an error of the weights")
+	}
+}
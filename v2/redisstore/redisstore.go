@@ -0,0 +1,187 @@
+// Package redisstore keeps a named distribution in Redis, so a fleet of instances all draw
+// from one centrally managed set of weights. The package doesn't depend on a Redis client,
+// any client is used through the Client interface, e.g. for go-redis:
+//
+//	type client struct{ *redis.Client }
+//
+//	func (c client) Get(ctx context.Context, key string) (string, error) {
+//		return c.Client.Get(ctx, key).Result()
+//	}
+//
+//	func (c client) Set(ctx context.Context, key, value string) error {
+//		return c.Client.Set(ctx, key, value, 0).Err()
+//	}
+//
+// Example usage:
+//
+//	store := redisstore.New[string](client{rdb}, "checkout")
+//	sampler, err := store.Lazy(ctx)
+//	if err != nil {
+//		panic(err) // Error handlers
+//	}
+//	variant := sampler.Random()
+//
+//	The weights are polled with the ttl of the Lazy sampler. For the changes to be seen at once,
+//	subscribe to the keyspace notifications of store.Key() and pass them to Sync.
+package redisstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	discreteprobability "github.com/peterli110/discreteprobability/v2"
+)
+
+// ErrValues is returned when the values of the distribution in Redis have changed since
+// the sampler was created. The weights are only updated for the same values.
+var ErrValues = errors.New("values of the distribution changed")
+
+// KeyPrefix is the prefix of the Redis keys of the distributions.
+const KeyPrefix = "discreteprobability:"
+
+// Client is the subset of a Redis client used by the Store.
+type Client interface {
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key, value string) error
+}
+
+// distribution is the JSON form of a distribution in Redis.
+type distribution struct {
+	Values  json.RawMessage `json:"values"`
+	Weights []float64       `json:"weights"`
+}
+
+// DefaultTimeout is how long a Lazy sampler waits for Redis when it polls the weights,
+// unless set with WithTimeout.
+const DefaultTimeout = 5 * time.Second
+
+// Store reads and writes a named distribution with values of type T.
+type Store[T any] struct {
+	client  Client
+	name    string
+	timeout time.Duration
+}
+
+// New returns a new Store of the distribution name.
+func New[T any](client Client, name string) *Store[T] {
+	return &Store[T]{client: client, name: name, timeout: DefaultTimeout}
+}
+
+// WithTimeout sets how long the Lazy samplers created after it wait for Redis when they
+// poll the weights, and returns s. The draws wait for the poll, so it should be short.
+func (s *Store[T]) WithTimeout(timeout time.Duration) *Store[T] {
+	s.timeout = timeout
+	return s
+}
+
+// Key returns the Redis key of the distribution.
+func (s *Store[T]) Key() string {
+	return KeyPrefix + s.name
+}
+
+// Save writes the values and weights of the distribution. The weights are checked as in
+// discreteprobability.New first, so an invalid set is never seen by the fleet.
+func (s *Store[T]) Save(ctx context.Context, values []T, weights []float64) error {
+	if _, err := discreteprobability.New(values, weights); err != nil {
+		return err
+	}
+	v, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(distribution{Values: v, Weights: weights})
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, s.Key(), string(data))
+}
+
+// load reads the distribution.
+func (s *Store[T]) load(ctx context.Context) (distribution, error) {
+	var d distribution
+	data, err := s.client.Get(ctx, s.Key())
+	if err != nil {
+		return d, err
+	}
+	if err := json.Unmarshal([]byte(data), &d); err != nil {
+		return d, err
+	}
+	d.Values, err = compact(d.Values)
+	return d, err
+}
+
+func compact(raw json.RawMessage) (json.RawMessage, error) {
+	var b bytes.Buffer
+	if err := json.Compact(&b, raw); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// Load reads the values and weights of the distribution.
+func (s *Store[T]) Load(ctx context.Context) ([]T, []float64, error) {
+	d, err := s.load(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	var values []T
+	if err := json.Unmarshal(d.Values, &values); err != nil {
+		return nil, nil, err
+	}
+	return values, d.Weights, nil
+}
+
+// Lazy returns a Lazy sampler of the distribution, whose weights are read from Redis again
+// after its ttl, waiting up to the timeout of the store. If the values in Redis have changed,
+// the old weights are kept and the Err of the sampler is ErrValues, a new sampler is needed
+// for the new values.
+func (s *Store[T]) Lazy(ctx context.Context) (*discreteprobability.Lazy[T], error) {
+	d, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var values []T
+	if err := json.Unmarshal(d.Values, &values); err != nil {
+		return nil, err
+	}
+
+	first, timeout := true, s.timeout
+	provider := discreteprobability.WeightProviderFunc(func() ([]float64, error) {
+		// the weights just read are used to create the sampler
+		if first {
+			first = false
+			return d.Weights, nil
+		}
+		// ctx may be done by the next poll, and a draw holds the lock of the sampler while it waits
+		pollCtx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		n, err := s.load(pollCtx)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(n.Values, d.Values) {
+			return nil, ErrValues
+		}
+		return n.Weights, nil
+	})
+	return discreteprobability.NewLazy(values, provider)
+}
+
+// Sync refreshes the sampler on every notification, e.g. the keyspace notifications of Key,
+// until ctx is done or notifications is closed.
+func Sync[T any](ctx context.Context, sampler *discreteprobability.Lazy[T], notifications <-chan struct{}) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-notifications:
+			if !ok {
+				return
+			}
+			sampler.Refresh()
+		}
+	}
+}
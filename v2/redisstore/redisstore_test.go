@@ -0,0 +1,127 @@
+package redisstore
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	discreteprobability "github.com/peterli110/discreteprobability/v2"
+)
+
+var errNil = errors.New("redis: nil")
+
+type fakeClient struct {
+	mu   sync.Mutex
+	data map[string]string
+	// hang makes Get wait for ctx, as for an unreachable server
+	hang bool
+}
+
+func (c *fakeClient) Get(ctx context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.hang {
+		<-ctx.Done()
+		return "", ctx.Err()
+	}
+	v, ok := c.data[key]
+	if !ok {
+		return "", errNil
+	}
+	return v, nil
+}
+
+func (c *fakeClient) Set(ctx context.Context, key, value string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data[key] = value
+	return nil
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeClient{data: map[string]string{}}
+	store := New[string](client, "checkout")
+	if _, err := store.Lazy(ctx); err != errNil {
+		t.Errorf("expected the client error, got %v", err)
+	}
+	if err := store.Save(ctx, []string{"a", "b"}, []float64{0.5, 0.6}); err != discreteprobability.ErrWeightSum {
+		t.Errorf("expected ErrWeightSum, got %v", err)
+	}
+
+	if err := store.Save(ctx, []string{"a", "b"}, []float64{1, 0}); err != nil {
+		t.Errorf("Save error %v", err)
+		t.FailNow()
+	}
+	values, weights, err := store.Load(ctx)
+	if err != nil || len(values) != 2 || values[1] != "b" || weights[0] != 1 {
+		t.Errorf("unexpected Load %v %v %v", values, weights, err)
+	}
+
+	sampler, err := store.Lazy(ctx)
+	if err != nil {
+		t.Errorf("Lazy error %v", err)
+		t.FailNow()
+	}
+	if v := sampler.Random(); v != "a" {
+		t.Errorf("expected a, got %v", v)
+	}
+
+	// another instance changes the weights
+	New[string](client, "checkout").Save(ctx, []string{"a", "b"}, []float64{0, 1})
+	if err := sampler.Refresh(); err != nil {
+		t.Errorf("Refresh error %v", err)
+	}
+	if v := sampler.Random(); v != "b" {
+		t.Errorf("expected b, got %v", v)
+	}
+
+	store.Save(ctx, []string{"a", "c"}, []float64{1, 0})
+	if err := sampler.Refresh(); err != ErrValues {
+		t.Errorf("expected ErrValues, got %v", err)
+	}
+	if v := sampler.Random(); v != "b" {
+		t.Errorf("expected the old weights, got %v", v)
+	}
+}
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	store := New[int](&fakeClient{data: map[string]string{}}, "n")
+	store.Save(ctx, []int{1, 2}, []float64{1, 0})
+	sampler, _ := store.Lazy(ctx)
+
+	notifications := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		Sync(ctx, sampler, notifications)
+		close(done)
+	}()
+	store.Save(ctx, []int{1, 2}, []float64{0, 1})
+	notifications <- struct{}{}
+	close(notifications)
+	<-done
+	if v := sampler.Random(); v != 2 {
+		t.Errorf("expected 2 after the notification, got %v", v)
+	}
+}
+
+func TestLazyTimeout(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeClient{data: map[string]string{}}
+	store := New[int](client, "n").WithTimeout(10 * time.Millisecond)
+	store.Save(ctx, []int{1, 2}, []float64{1, 0})
+	sampler, _ := store.Lazy(ctx)
+
+	client.mu.Lock()
+	client.hang = true
+	client.mu.Unlock()
+	if err := sampler.Refresh(); err != context.DeadlineExceeded {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+	if v := sampler.Random(); v != 1 {
+		t.Errorf("expected the old weights, got %v", v)
+	}
+}
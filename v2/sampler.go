@@ -0,0 +1,26 @@
+package discreteprobability
+
+// Sampler is the interface of a source of random values of type T. Code which draws values
+// should depend on a Sampler instead of a *Generator, so the draws can be scripted in tests,
+// see the discreteprobabilitytest package, or made by another backend.
+type Sampler[T any] interface {
+	Random() T
+}
+
+// BatchSampler is a Sampler which draws n values at once.
+type BatchSampler[T any] interface {
+	Sampler[T]
+	SampleN(n int) []T
+}
+
+// WeightedUpdater is implemented by the samplers whose weights can be replaced without
+// building a new one, e.g. when the weights are learned online. The weights are in the
+// order of the values.
+type WeightedUpdater interface {
+	SetWeights(weights []float64) error
+}
+
+var (
+	_ BatchSampler[int] = (*Generator[int])(nil)
+	_ WeightedUpdater   = (*Generator[int])(nil)
+)
@@ -0,0 +1,43 @@
+package discreteprobability
+
+import "fmt"
+
+// resolution is the smallest gap between the random floats drawn by the generator.
+const resolution = 1.0 / (1 << 53)
+
+// Warning is a problem of a value found by Validate
+type Warning struct {
+	Value   interface{}
+	Message string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%v: %s", w.Value, w.Message)
+}
+
+// Validate returns the warnings of the values which can never be drawn, such as the
+// values with zero weights or tiny weights swallowed by rounding of the cumulative sum,
+// and the values whose probability is below the resolution of the random source.
+func (g *Generator) Validate() []Warning {
+	var warnings []Warning
+	for i, value := range g.values {
+		if g.exact != nil {
+			if i == 0 && g.exact.cumulative[0].Sign() == 0 ||
+				i > 0 && g.exact.cumulative[i].Cmp(g.exact.cumulative[i-1]) == 0 {
+				warnings = append(warnings, Warning{Value: value.Interface(), Message: "unreachable: weight is zero"})
+			}
+			continue
+		}
+
+		switch p := g.probability(i); {
+		case p <= 0:
+			warnings = append(warnings, Warning{Value: value.Interface(), Message: "unreachable: effective probability is zero"})
+		case p < resolution:
+			warnings = append(warnings, Warning{
+				Value:   value.Interface(),
+				Message: fmt.Sprintf("may be unreachable: effective probability %g is below the resolution of the random source", p),
+			})
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,34 @@
+package discreteprobability
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	g, _ := New([]string{"common", "rare", "never"}, []float64{1, 1e-20, 0})
+	warnings := g.Validate()
+	if len(warnings) != 2 {
+		t.Errorf("expected 2 warnings, got %v", warnings)
+		t.FailNow()
+	}
+	for _, w := range warnings {
+		if w.Value != "rare" && w.Value != "never" {
+			t.Errorf("unexpected warning %v", w)
+		}
+	}
+
+	g = generateInt(t, 1, sliceLen)
+	if warnings := g.Validate(); len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", warnings)
+	}
+}
+
+func TestValidateExact(t *testing.T) {
+	tiny := big.NewRat(1, 1000000000000000000)
+	g, _ := NewExact([]string{"common", "rare", "never"}, []*big.Rat{new(big.Rat).Sub(big.NewRat(1, 1), tiny), tiny, new(big.Rat)})
+	warnings := g.Validate()
+	if len(warnings) != 1 || warnings[0].Value != "never" {
+		t.Errorf("expected a warning of never, got %v", warnings)
+	}
+}
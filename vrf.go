@@ -0,0 +1,60 @@
+package discreteprobability
+
+import "reflect"
+
+// VRFProver is the secret side of a verifiable random function, e.g. ECVRF: the output is
+// a pseudorandom function of the input under the private key, and the proof lets anyone with
+// the public key check it.
+type VRFProver interface {
+	Prove(input []byte) (output, proof []byte, err error)
+}
+
+// VRFVerifier is the public side of a verifiable random function. Verify reports whether
+// the output and the proof are the ones of the input.
+type VRFVerifier interface {
+	Verify(input, output, proof []byte) bool
+}
+
+// RandomVRF returns the value drawn with the output of the VRF for the input, e.g. a block hash,
+// together with the output and the proof of the VRF. The draw can be checked with VerifyVRF by anyone
+// with a generator of the same values and weights, in any order for numbers and strings.
+// The random stream of the generator is not touched.
+// It will return the error of the VRF, or ErrShortOutput if the output is shorter than 8 bytes
+func (g *Generator) RandomVRF(prover VRFProver, input []byte) (interface{}, []byte, []byte, error) {
+	output, proof, err := prover.Prove(input)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	i, err := g.vrfIndex(output)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	g.observe(i, false)
+	return g.values[i].Interface(), output, proof, nil
+}
+
+// VerifyVRF checks a draw of RandomVRF. It will return ErrProof if the output and the proof are not
+// the ones of the input or the value is not the one of the output, or ErrShortOutput if the output
+// is shorter than 8 bytes
+func (g *Generator) VerifyVRF(verifier VRFVerifier, input, output, proof []byte, value interface{}) error {
+	if !verifier.Verify(input, output, proof) {
+		return ErrProof
+	}
+	i, err := g.vrfIndex(output)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(g.values[i].Interface(), value) {
+		return ErrProof
+	}
+	return nil
+}
+
+// vrfIndex returns the index drawn with the uniform of the output, in ascending order of the values.
+func (g *Generator) vrfIndex(output []byte) (int, error) {
+	u, err := UniformFromBytes(output)
+	if err != nil {
+		return 0, err
+	}
+	return g.uniformIndex(u), nil
+}
@@ -0,0 +1,84 @@
+package discreteprobability
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"testing"
+)
+
+// hmacVRF is a stand-in of a VRF for the tests: the output is an HMAC, and the proof is the key.
+// A real VRF proves the output without revealing the key.
+type hmacVRF struct {
+	key   []byte
+	short bool
+	err   error
+}
+
+func (v hmacVRF) Prove(input []byte) ([]byte, []byte, error) {
+	mac := hmac.New(sha256.New, v.key)
+	mac.Write(input)
+	output := mac.Sum(nil)
+	if v.short {
+		output = output[:4]
+	}
+	return output, v.key, v.err
+}
+
+func (v hmacVRF) Verify(input, output, proof []byte) bool {
+	mac := hmac.New(sha256.New, proof)
+	mac.Write(input)
+	return hmac.Equal(mac.Sum(nil), output)
+}
+
+func TestRandomVRF(t *testing.T) {
+	g, _ := New([]int{1, 2, 3}, []float64{0.2, 0.3, 0.5})
+	vrf := hmacVRF{key: []byte("key")}
+	v, output, proof, err := g.RandomVRF(vrf, []byte("block 42"))
+	if err != nil {
+		t.Errorf("RandomVRF error %v", err)
+		t.FailNow()
+	}
+	if err := g.VerifyVRF(vrf, []byte("block 42"), output, proof, v); err != nil {
+		t.Errorf("VerifyVRF error %v", err)
+	}
+	if err := g.VerifyVRF(vrf, []byte("block 43"), output, proof, v); err != ErrProof {
+		t.Errorf("expected ErrProof for another input, got %v", err)
+	}
+	u, _ := UniformFromBytes(output)
+	if expected, _ := g.RandomFromUniform(u); expected != v {
+		t.Errorf("expected %v from the uniform of the output, got %v", expected, v)
+	}
+	if err := g.VerifyVRF(vrf, []byte("block 42"), output, proof, v.(int)%3+1); err != ErrProof {
+		t.Errorf("expected ErrProof for another value, got %v", err)
+	}
+}
+
+func TestVerifyVRFShuffled(t *testing.T) {
+	g, _ := New([]string{"a", "b", "c", "d"}, []float64{0.25, 0.25, 0.25, 0.25})
+	verifier, _ := New([]string{"d", "c", "b", "a"}, []float64{0.25, 0.25, 0.25, 0.25})
+	vrf := hmacVRF{key: []byte("key")}
+	for i := 0; i < 100; i++ {
+		input := []byte{byte(i)}
+		v, output, proof, err := g.RandomVRF(vrf, input)
+		if err != nil {
+			t.Errorf("RandomVRF error %v", err)
+			t.FailNow()
+		}
+		if err := verifier.VerifyVRF(vrf, input, output, proof, v); err != nil {
+			t.Errorf("VerifyVRF error %v for input %v", err, i)
+			t.FailNow()
+		}
+	}
+}
+
+func TestRandomVRFErrors(t *testing.T) {
+	g, _ := New([]int{1, 2}, []float64{0.5, 0.5})
+	fail := errors.New("failed")
+	if _, _, _, err := g.RandomVRF(hmacVRF{err: fail}, nil); err != fail {
+		t.Errorf("expected the VRF error, got %v", err)
+	}
+	if _, _, _, err := g.RandomVRF(hmacVRF{short: true}, nil); err != ErrShortOutput {
+		t.Errorf("expected ErrShortOutput, got %v", err)
+	}
+}
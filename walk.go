@@ -0,0 +1,39 @@
+package discreteprobability
+
+import (
+	"math/rand"
+	"time"
+)
+
+// RandomWalk walks steps steps from start and returns the visited nodes, starting with start.
+// At each step the walk jumps back to start with the probability restartProb,
+// otherwise it moves to a node drawn from the generator returned by next for the
+// current node. A nil generator is a dead end, and the walk restarts from there.
+// A walk of fewer than 0 steps is only start.
+func RandomWalk(start interface{}, steps int, restartProb float64, next func(interface{}) *Generator) []interface{} {
+	return RandomWalkFrom(rand.NewSource(time.Now().UnixNano()), start, steps, restartProb, next)
+}
+
+// RandomWalkFrom is as RandomWalk, but the restarts are drawn with the randomness of source,
+// which is owned by the caller. The moves are still drawn by the generators returned by next,
+// so a walk is repeatable when both source and the generators are seeded.
+func RandomWalkFrom(source rand.Source, start interface{}, steps int, restartProb float64, next func(interface{}) *Generator) []interface{} {
+	if steps < 0 {
+		steps = 0
+	}
+	path := make([]interface{}, 0, steps+1)
+	path = append(path, start)
+
+	current := start
+	for i := 0; i < steps; i++ {
+		if uniform(source) < restartProb {
+			current = start
+		} else if g := next(current); g == nil || g.size == 0 {
+			current = start
+		} else {
+			current = g.RandomInterface()
+		}
+		path = append(path, current)
+	}
+	return path
+}
@@ -0,0 +1,84 @@
+package discreteprobability
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRandomWalk(t *testing.T) {
+	graph := walkGraph(t, time.Now().Unix())
+	next := func(node interface{}) *Generator { return graph[node.(string)] }
+
+	path := RandomWalk("a", 100, 0, next)
+	if len(path) != 101 || path[0] != "a" {
+		t.Errorf("unexpected path %v", path)
+		t.FailNow()
+	}
+	for i := 1; i < len(path); i++ {
+		if !contains(graph[path[i-1].(string)], path[i]) {
+			t.Errorf("invalid step %v -> %v", path[i-1], path[i])
+			t.FailNow()
+		}
+	}
+
+	for _, node := range RandomWalk("a", 10, 1, next) {
+		if node != "a" {
+			t.Errorf("expected to always restart, got %v", node)
+		}
+	}
+	if path := RandomWalk("a", -1, 0, next); len(path) != 1 || path[0] != "a" {
+		t.Errorf("expected only the start for negative steps, got %v", path)
+	}
+	for _, node := range RandomWalk("x", 10, 0, next) {
+		if node != "x" {
+			t.Errorf("expected to restart at dead end, got %v", node)
+		}
+	}
+}
+
+func TestRandomWalkFrom(t *testing.T) {
+	graph, replay := walkGraph(t, 1), walkGraph(t, 1)
+	path := RandomWalkFrom(rand.NewSource(42), "a", 100, 0.3, func(node interface{}) *Generator {
+		return graph[node.(string)]
+	})
+	replayed := RandomWalkFrom(rand.NewSource(42), "a", 100, 0.3, func(node interface{}) *Generator {
+		return replay[node.(string)]
+	})
+	if !reflect.DeepEqual(path, replayed) {
+		t.Errorf("expected the same walk with the same seeds, got %v and %v", path, replayed)
+	}
+}
+
+// walkGraph returns the generators of the neighbors of every node, seeded with seed.
+func walkGraph(t *testing.T, seed int64) map[string]*Generator {
+	graph := map[string]*Generator{}
+	for node, neighbors := range map[string][]string{
+		"a": {"b", "c"},
+		"b": {"c"},
+		"c": {"a"},
+	} {
+		weights := make([]float64, len(neighbors))
+		for i := range weights {
+			weights[i] = 1 / float64(len(neighbors))
+		}
+		g, err := New(neighbors, weights)
+		if err != nil {
+			t.Errorf("New error %v", err)
+			t.FailNow()
+		}
+		g.SetSeed(seed)
+		graph[node] = g
+	}
+	return graph
+}
+
+func contains(g *Generator, v interface{}) bool {
+	for _, value := range g.values {
+		if value.Interface() == v {
+			return true
+		}
+	}
+	return false
+}
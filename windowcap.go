@@ -0,0 +1,84 @@
+package discreteprobability
+
+import (
+	"reflect"
+	"time"
+)
+
+// WindowCapped is a Generator whose values have caps of draws within a rolling window.
+// A value which has hit its cap is excluded until its oldest draw leaves the window,
+// and the rest of the values are renormalized.
+type WindowCapped struct {
+	g    *Generator
+	caps []windowCap
+	now  func() time.Time
+}
+
+// windowCap is the cap of the value at indexes. draws are the times of its draws within
+// the window, the oldest first.
+type windowCap struct {
+	indexes []int
+	max     int
+	window  time.Duration
+	draws   []time.Time
+}
+
+// WithWindowCap returns a WindowCapped over the values of g, in which the value v is drawn
+// at most max times within any window, e.g. an ad shown at most 3 times an hour.
+// It will return ErrValue if v is not one of the values of g
+func (g *Generator) WithWindowCap(v interface{}, max int, window time.Duration) (*WindowCapped, error) {
+	w := &WindowCapped{g: g, now: time.Now}
+	return w.WithWindowCap(v, max, window)
+}
+
+// WithWindowCap adds the cap of the value v to w and returns w, so the caps of more values
+// can be chained, as well as more caps of the same value such as 3 an hour and 10 a day.
+// It will return ErrValue if v is not one of the values of the generator
+func (w *WindowCapped) WithWindowCap(v interface{}, max int, window time.Duration) (*WindowCapped, error) {
+	c := windowCap{max: max, window: window}
+	for i, value := range w.g.values {
+		if reflect.DeepEqual(value.Interface(), v) {
+			c.indexes = append(c.indexes, i)
+		}
+	}
+	if c.indexes == nil {
+		return nil, ErrValue
+	}
+	w.caps = append(w.caps, c)
+	return w, nil
+}
+
+// Random returns a value drawn from the values under their caps.
+// It will return ErrNotEnough if all the values with positive weights are capped.
+func (w *WindowCapped) Random() (interface{}, error) {
+	now := w.now()
+	buf := w.g.getPicked(w.g.size)
+	defer w.g.putPicked(buf)
+	capped := *buf
+	for c := range w.caps {
+		wc := &w.caps[c]
+		expired := 0
+		for expired < len(wc.draws) && now.Sub(wc.draws[expired]) >= wc.window {
+			expired++
+		}
+		wc.draws = wc.draws[expired:]
+		if len(wc.draws) >= wc.max {
+			for _, index := range wc.indexes {
+				capped[index] = true
+			}
+		}
+	}
+
+	i := w.g.drawWithout(capped, func(int) bool { return true })
+	if i < 0 {
+		return nil, ErrNotEnough
+	}
+	for c := range w.caps {
+		for _, index := range w.caps[c].indexes {
+			if index == i {
+				w.caps[c].draws = append(w.caps[c].draws, now)
+			}
+		}
+	}
+	return w.g.values[i].Interface(), nil
+}
@@ -0,0 +1,69 @@
+package discreteprobability
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithWindowCap(t *testing.T) {
+	g, _ := New([]string{"a", "b"}, []float64{0.9, 0.1})
+	g.SetSeed(time.Now().Unix())
+	w, err := g.WithWindowCap("a", 3, time.Hour)
+	if err != nil {
+		t.Errorf("WithWindowCap error %v", err)
+		t.FailNow()
+	}
+	now := time.Now()
+	w.now = func() time.Time { return now }
+
+	count := func() int {
+		n := 0
+		for i := 0; i < 100; i++ {
+			v, err := w.Random()
+			if err != nil {
+				t.Errorf("Random error %v", err)
+				t.FailNow()
+			}
+			if v == "a" {
+				n++
+			}
+			now = now.Add(time.Second)
+		}
+		return n
+	}
+	if n := count(); n != 3 {
+		t.Errorf("expected 3 draws of a within an hour, got %v", n)
+	}
+
+	now = now.Add(time.Hour)
+	if n := count(); n != 3 {
+		t.Errorf("expected 3 draws of a after the window, got %v", n)
+	}
+
+	if _, err := w.WithWindowCap("c", 1, time.Hour); err != ErrValue {
+		t.Errorf("expected ErrValue, got %v", err)
+	}
+}
+
+func TestWithWindowCapNotEnough(t *testing.T) {
+	g, _ := New([]string{"a", "b"}, []float64{1, 0})
+	w, err := g.WithWindowCap("a", 1, time.Minute)
+	if err != nil {
+		t.Errorf("WithWindowCap error %v", err)
+		t.FailNow()
+	}
+	now := time.Now()
+	w.now = func() time.Time { return now }
+
+	if _, err := w.Random(); err != nil {
+		t.Errorf("Random error %v", err)
+		t.FailNow()
+	}
+	if _, err := w.Random(); err != ErrNotEnough {
+		t.Errorf("expected ErrNotEnough, got %v", err)
+	}
+	now = now.Add(time.Minute)
+	if _, err := w.Random(); err != nil {
+		t.Errorf("Random error %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+// Package workqueue is a queue whose items are dequeued with probabilities proportional
+// to their weights, drawn from a discreteprobability.Generator. The waiting items are aged,
+// so the low-weight items can't starve.
+// Example usage:
+//
+//		q := workqueue.New(workqueue.LinearAging(0.1))
+//		q.Enqueue("backup", 1)
+//		q.Enqueue("request", 10)
+//		job, err := q.Dequeue()
+//		if err != nil {
+//			panic(err) // Error handlers
+//		}
+package workqueue
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+// ErrEmpty is returned when the queue has no items
+var ErrEmpty = errors.New("queue is empty")
+
+// Aging is the policy of the effective weight of an item which has been passed over by
+// waited dequeues. It should not decrease with waited.
+type Aging func(weight float64, waited int) float64
+
+// NoAging keeps the weights, so a low-weight item may wait for long.
+func NoAging(weight float64, waited int) float64 {
+	return weight
+}
+
+// LinearAging returns the policy which raises the weight by rate of itself on every dequeue
+// the item is passed over, e.g. a rate of 0.1 doubles the weight after 10 dequeues.
+// An item with zero weight gains rate on every dequeue instead.
+func LinearAging(rate float64) Aging {
+	return func(weight float64, waited int) float64 {
+		if weight == 0 {
+			return rate * float64(waited)
+		}
+		return weight * (1 + rate*float64(waited))
+	}
+}
+
+type item struct {
+	value  interface{}
+	weight float64
+	waited int
+}
+
+// Queue is a weighted queue with aging. It's safe for concurrent use.
+type Queue struct {
+	mu    sync.Mutex
+	aging Aging
+	items []item
+	rnd   *rand.Rand
+}
+
+// New returns a new Queue with the aging policy, which is NoAging if nil.
+func New(aging Aging) *Queue {
+	if aging == nil {
+		aging = NoAging
+	}
+	return &Queue{
+		aging: aging,
+		rnd:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// SetSeed is to set a custom random seed other than the time stamp.
+func (q *Queue) SetSeed(s int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.rnd = rand.New(rand.NewSource(s))
+}
+
+// Enqueue adds the value with the weight.
+// It will return ErrNegativeWeight if the weight is negative
+func (q *Queue) Enqueue(v interface{}, weight float64) error {
+	if weight < 0 {
+		return discreteprobability.ErrNegativeWeight
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.items = append(q.items, item{value: v, weight: weight})
+	return nil
+}
+
+// Len returns the number of items in the queue.
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+// Dequeue removes and returns an item drawn with the aged weights. If none of the items
+// has a positive weight, the oldest one is returned.
+// It will return ErrEmpty if the queue has no items
+func (q *Queue) Dequeue() (interface{}, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.items) == 0 {
+		return nil, ErrEmpty
+	}
+
+	indexes := make([]int, len(q.items))
+	weights := make([]float64, len(q.items))
+	for i, it := range q.items {
+		indexes[i] = i
+		weights[i] = q.aging(it.weight, it.waited)
+	}
+	i := 0
+	if g, err := discreteprobability.NewNormalized(indexes, weights); err == nil {
+		g.SetSeed(q.rnd.Int63())
+		i = g.RandomInt()
+	}
+
+	v := q.items[i].value
+	q.items = append(q.items[:i], q.items[i+1:]...)
+	for j := range q.items {
+		q.items[j].waited++
+	}
+	return v, nil
+}
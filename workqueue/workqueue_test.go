@@ -0,0 +1,76 @@
+package workqueue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/peterli110/discreteprobability"
+)
+
+const repeats = 10000
+
+func TestDequeue(t *testing.T) {
+	q := New(nil)
+	q.SetSeed(time.Now().Unix())
+	count := 0
+	for i := 0; i < repeats; i++ {
+		q.Enqueue("low", 1)
+		q.Enqueue("high", 3)
+		v, err := q.Dequeue()
+		if err != nil {
+			t.Errorf("Dequeue error %v", err)
+			t.FailNow()
+		}
+		if v == "high" {
+			count++
+		}
+		q.Dequeue()
+	}
+	p := float64(repeats) * 0.75
+	if d := p * 3 / 100; float64(count) > p+d || float64(count) < p-d {
+		t.Errorf("incorrect distribution, expected %f, got %d", p, count)
+	}
+
+	if _, err := q.Dequeue(); err != ErrEmpty {
+		t.Errorf("expected ErrEmpty, got %v", err)
+	}
+	if err := q.Enqueue("a", -1); err != discreteprobability.ErrNegativeWeight {
+		t.Errorf("expected ErrNegativeWeight, got %v", err)
+	}
+}
+
+func TestAging(t *testing.T) {
+	// a steady stream of heavy items would starve the light one without aging
+	wait := func(aging Aging) int {
+		q := New(aging)
+		q.SetSeed(1)
+		q.Enqueue("light", 0.001)
+		for i := 0; i < 1000; i++ {
+			q.Enqueue("heavy", 1)
+			if v, _ := q.Dequeue(); v == "light" {
+				return i
+			}
+		}
+		return -1
+	}
+
+	aged := wait(LinearAging(1))
+	if aged < 0 || aged > 200 {
+		t.Errorf("expected the light item within 200 dequeues with aging, got %v", aged)
+	}
+	if plain := wait(NoAging); plain >= 0 && plain < aged {
+		t.Errorf("expected the light item later without aging, got %v and %v", plain, aged)
+	}
+}
+
+func TestZeroWeights(t *testing.T) {
+	q := New(nil)
+	q.Enqueue("first", 0)
+	q.Enqueue("second", 0)
+	if v, _ := q.Dequeue(); v != "first" {
+		t.Errorf("expected the oldest item, got %v", v)
+	}
+	if q.Len() != 1 {
+		t.Errorf("expected 1 item, got %v", q.Len())
+	}
+}